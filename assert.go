@@ -0,0 +1,37 @@
+package cronschedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AssertFires generates len(_expected_) executions of the schedule _s_ after _from_ and compares them to _expected_.
+// Nil is returned when every execution is equal to the expected time at the same index. Otherwise the error lists each
+// mismatched execution, making it convenient for checking schedules against a golden list of times in tests. An error
+// is returned without generating any executions if the schedule can never execute.
+func AssertFires(s Schedule, from time.Time, expected []time.Time) error {
+	if len(expected) == 0 {
+		return nil
+	}
+
+	if !s.IsSatisfiable() {
+		return fmt.Errorf("schedule [%s] never executes but %d executions were expected", s.ScheduleStr, len(expected))
+	}
+
+	mismatches := make([]string, 0)
+	for i, execT := range s.NextExecutions(from, len(expected)) {
+		if i >= len(expected) {
+			break
+		}
+
+		if !execT.Equal(expected[i]) {
+			mismatches = append(mismatches, fmt.Sprintf("execution %d: expected %v but received %v", i, expected[i], execT))
+		}
+	}
+
+	if len(mismatches) != 0 {
+		return fmt.Errorf("schedule [%s] after %v has %d mismatched executions: %s", s.ScheduleStr, from, len(mismatches), strings.Join(mismatches, "; "))
+	}
+	return nil
+}
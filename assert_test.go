@@ -0,0 +1,58 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssertFires(t *testing.T) {
+	for _, param := range CronTestData {
+		schedule, err := cronschedule.Parse(param.Schedule)
+		if err != nil {
+			t.Errorf("%d|failed to build schedule for %s: %s", param.ID, param.Schedule, err)
+			continue
+		}
+
+		expected := make([]time.Time, 0, len(param.ExpectedResults))
+		for _, result := range param.ExpectedResults {
+			execT, err := time.ParseInLocation("2006-01-02 15:04:05", result, time.Local)
+			if err != nil {
+				t.Fatalf("%d|failed to parse time %s: %s", param.ID, result, err)
+			}
+			expected = append(expected, execT)
+		}
+
+		if err := cronschedule.AssertFires(schedule, param.T, expected); err != nil {
+			t.Errorf("%d|%s", param.ID, err)
+		}
+	}
+
+	schedule, err := cronschedule.Parse("0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	from := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+	expected := []time.Time{
+		time.Date(2020, time.July, 23, 22, 0, 0, 0, time.Local),
+		time.Date(2020, time.July, 25, 22, 0, 0, 0, time.Local),
+	}
+
+	err = cronschedule.AssertFires(schedule, from, expected)
+	if err == nil {
+		t.Fatalf("expected error for a mismatched execution")
+	}
+	if !strings.Contains(err.Error(), "1 mismatched executions") || !strings.Contains(err.Error(), "execution 1:") {
+		t.Errorf("expected error describing execution 1 but received %s", err)
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if err := cronschedule.AssertFires(unsatisfiable, from, expected); err == nil || !strings.Contains(err.Error(), "never executes") {
+		t.Errorf("expected an error for a schedule that never executes but received %v", err)
+	}
+}
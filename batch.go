@@ -0,0 +1,54 @@
+package cronschedule
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// batchParallelThreshold is the number of schedules at which NextExecutionsBatch splits the work across workers.
+// Smaller batches are computed serially as starting the workers costs more than it saves.
+const batchParallelThreshold = 256
+
+// NextExecutionsBatch returns the next execution after _from_ of each schedule in _schedules_. The time at index i is
+// the next execution of the schedule at index i, or the zero time if the schedule can never execute. Large batches are
+// split across one worker per CPU.
+func NextExecutionsBatch(schedules []Schedule, from time.Time) []time.Time {
+	execTimes := make([]time.Time, len(schedules))
+
+	next := func(i int) {
+		if schedules[i].IsSatisfiable() {
+			execTimes[i] = schedules[i].NextExecution(from)
+		}
+	}
+
+	if len(schedules) < batchParallelThreshold {
+		for i := range schedules {
+			next(i)
+		}
+		return execTimes
+	}
+
+	// Each worker computes a contiguous chunk of the schedules.
+	workers := runtime.NumCPU()
+	chunk := (len(schedules) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(schedules); start += chunk {
+		end := start + chunk
+		if end > len(schedules) {
+			end = len(schedules)
+		}
+
+		wg.Add(1)
+		go func(start int, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				next(i)
+			}
+		}(start, end)
+	}
+
+	wg.Wait()
+	return execTimes
+}
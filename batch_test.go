@@ -0,0 +1,59 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+	"time"
+)
+
+func TestNextExecutionsBatch(t *testing.T) {
+	from := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+
+	// Covering both the serial and the parallel paths.
+	for _, count := range []int{10, 1000} {
+		schedules := syntheticSchedules(count)
+
+		unsatisfiable, err := cronschedule.Parse("0 0 31 2 *")
+		if err != nil {
+			t.Fatalf("failed to build schedule: %s", err)
+		}
+		schedules = append(schedules, unsatisfiable)
+
+		execTimes := cronschedule.NextExecutionsBatch(schedules, from)
+		if len(execTimes) != len(schedules) {
+			t.Fatalf("expected %d execution times but received %d", len(schedules), len(execTimes))
+		}
+
+		for i := 0; i < count; i++ {
+			if expected := schedules[i].NextExecution(from); !execTimes[i].Equal(expected) {
+				t.Errorf("expected next execution %v for %s but received %v", expected, schedules[i].ScheduleStr, execTimes[i])
+			}
+		}
+
+		if !execTimes[count].IsZero() {
+			t.Errorf("expected zero time for an unsatisfiable schedule but received %v", execTimes[count])
+		}
+	}
+}
+
+func BenchmarkNextExecutionsBatch(b *testing.B) {
+	schedules := syntheticSchedules(1000)
+	from := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = cronschedule.NextExecutionsBatch(schedules, from)
+	}
+}
+
+func BenchmarkNextExecutionSerial(b *testing.B) {
+	schedules := syntheticSchedules(1000)
+	from := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range schedules {
+			_ = schedules[j].NextExecution(from)
+		}
+	}
+}
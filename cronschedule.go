@@ -5,6 +5,7 @@
 package cronschedule
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sort"
@@ -23,10 +24,15 @@ import (
 // 4 - [#-#/#] Numerical value range with interval
 // 5 - [#/#] Interval with start value
 // 6 - [#] Numerical value
-const CronFieldValueRegex = `(^\*$)|(^\*\/\d*$)|(^\d*-\d*$)|(^\d*-\d*\/\d*$)|(^\d*\/\d*$)|(^\d*$)`
+//
+// Every # requires at least one digit and may be zero padded, e.g. 0-09/02 is the same as 0-9/2.
+const CronFieldValueRegex = `(^\*$)|(^\*\/\d+$)|(^\d+-\d+$)|(^\d+-\d+\/\d+$)|(^\d+\/\d+$)|(^\d+$)`
 
 var re = regexp.MustCompile(CronFieldValueRegex)
 
+// now returns the current time. It is a variable so tests may provide a fixed clock.
+var now = time.Now
+
 const FieldMinuteMin int = 0
 const FieldMinuteMax int = 59
 const FieldHourMin int = 0
@@ -38,6 +44,22 @@ const FieldMonthMax int = 12
 const FieldDayOfTheWeekMin int = 0
 const FieldDayOfTheWeekMax int = 6
 
+// FieldCountError is provided by Parse when the schedule does not contain the expected number of fields. Hint contains
+// the likely cause of the mismatch when one could be determined.
+type FieldCountError struct {
+	Got  int
+	Want int
+	Hint string
+}
+
+// Error returns the error message.
+func (e *FieldCountError) Error() string {
+	if e.Hint != "" {
+		return fmt.Sprintf("schedule should have %d fields but found %d: %s", e.Want, e.Got, e.Hint)
+	}
+	return fmt.Sprintf("schedule should have %d fields but found %d", e.Want, e.Got)
+}
+
 // Schedule is a cron schedule. Parse should be utilized to generate Schedules.
 type Schedule struct {
 	Minutes      map[int]int
@@ -60,23 +82,230 @@ type Schedule struct {
 	DaysOfWeekSlice  []int
 	DaysOfTheWeekStr []string
 
+	// NthDaysOfTheWeek contains the occurrences of days of the week specified with the # operator. They are ORed with
+	// the days of the week the same as DaysOfTheWeek.
+	NthDaysOfTheWeek []NthWeekday
+
+	// DaysOfMonthCleared and DaysOfTheWeekCleared are true when Parse cleared the field as it was written as * while
+	// the other day field was not, or for the day of week, when both day fields were written as *.
+	DaysOfMonthCleared   bool
+	DaysOfTheWeekCleared bool
+
 	ScheduleStr string
+
+	// Location is the location the schedule is evaluated in as specified by a leading CRON_TZ token. When nil times
+	// are evaluated in their own location and generated in the local location.
+	Location *time.Location
+
+	// clock provides the current time for ShouldExecuteNow as configured by WithClock. The package clock is used when
+	// nil.
+	clock func() time.Time
+
+	// offset shifts every execution time of the schedule as configured by Offset.
+	offset time.Duration
+
+	// canonical caches the result of String for canonicalLocation. It is generated whenever the value slices are
+	// rebuilt or the location is set by a CRON_TZ token or WithLocation, and cleared by the Add methods.
+	canonical         string
+	canonicalLocation *time.Location
 }
 
-// PrettyString generates a multi line string containing the schedule and values within it.
+// PrettyString generates a multi line string containing the schedule and values within it. A day field cleared by Parse
+// is shown as ignored when the other day field was specified, or as * when both day fields were written as *, rather
+// than as an empty list of values.
 func (s *Schedule) PrettyString() string {
-	prettyString := ""
-	prettyString += fmt.Sprintf("Cron Schedule:     [%s]\n", s.ScheduleStr)
-	prettyString += fmt.Sprintf("Minute:            %s => [%#v]\n", s.MinutesStr, sortMapKeys(s.Minutes))
-	prettyString += fmt.Sprintf("Hour:              %s => [%#v]\n", s.HoursStr, sortMapKeys(s.Hours))
-	prettyString += fmt.Sprintf("Days Of The Month: %s => [%#v]\n", s.DaysOfMonthStr, sortMapKeys(s.DaysOfMonth))
-	prettyString += fmt.Sprintf("Month:             %s => [%#v]\n", s.MonthsStr, sortMapKeys(s.Months))
-	prettyString += fmt.Sprintf("Day Of The Week:   %s => [%#v]\n", s.DaysOfTheWeekStr, sortMapKeys(s.DaysOfTheWeek))
-	return prettyString
+	var b strings.Builder
+	b.Grow(320)
+	b.WriteString("Cron Schedule:     [")
+	b.WriteString(s.ScheduleStr)
+	b.WriteString("]\n")
+	writePrettyField(&b, "Minute:            ", s.MinutesStr)
+	writeGoSyntaxInts(&b, sortMapKeys(s.Minutes))
+	b.WriteString("]\n")
+	writePrettyField(&b, "Hour:              ", s.HoursStr)
+	writeGoSyntaxInts(&b, sortMapKeys(s.Hours))
+	b.WriteString("]\n")
+	writePrettyField(&b, "Days Of The Month: ", s.DaysOfMonthStr)
+	b.WriteString(s.prettyDayValues(2))
+	b.WriteString("]\n")
+	writePrettyField(&b, "Month:             ", s.MonthsStr)
+	writeGoSyntaxInts(&b, sortMapKeys(s.Months))
+	b.WriteString("]\n")
+	writePrettyField(&b, "Day Of The Week:   ", s.DaysOfTheWeekStr)
+	b.WriteString(s.prettyDayValues(4))
+	b.WriteString("]\n")
+	return b.String()
+}
+
+// writePrettyField writes the start of a PrettyString line for a field with the _label_ and the field _strs_ in the
+// form produced by %s, leaving the values to be written by the caller.
+func writePrettyField(b *strings.Builder, label string, strs []string) {
+	b.WriteString(label)
+	b.WriteByte('[')
+	b.WriteString(strings.Join(strs, " "))
+	b.WriteString("] => [")
+}
+
+// writeGoSyntaxInts writes the _values_ in the form produced by %#v, e.g. []int{1, 2}.
+func writeGoSyntaxInts(b *strings.Builder, values []int) {
+	b.WriteString("[]int{")
+	for i, value := range values {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Itoa(value))
+	}
+	b.WriteByte('}')
+}
+
+// prettyDayValues generates the values shown by PrettyString for the day field at index.
+func (s *Schedule) prettyDayValues(index int) string {
+	values := s.fieldMapByIndex(index)
+	cleared := s.DaysOfMonthCleared
+	if index == 4 {
+		cleared = s.DaysOfTheWeekCleared
+	}
+
+	if cleared && len(values) == 0 {
+		switch {
+		case index == 2:
+			return "(ignored, day-of-week specified)"
+		case len(s.DaysOfMonthStr) == 1 && s.DaysOfMonthStr[0] == "*":
+			// Both day fields were written as * so every day is included through the day of month.
+			return "*"
+		default:
+			return "(ignored, day-of-month specified)"
+		}
+	}
+
+	if index == 4 && len(s.NthDaysOfTheWeek) != 0 {
+		return fmt.Sprintf("%#v %v", sortMapKeys(values), s.NthDaysOfTheWeek)
+	}
+	return fmt.Sprintf("%#v", sortMapKeys(values))
+}
+
+// String returns the canonical cron schedule generated from the values resolved for each field rather than the
+// original ScheduleStr. Fields containing every value are written as *, value sets of three or more values forming a
+// uniform step from the field minimum that covers the full field are written as */n, and any other set is written as a
+// list with consecutive runs of three or more values collapsed into ranges. Fields without any values, such as a day
+// field cleared by Parse, are written as *. A day field containing every value is written as a range when the other
+// day field is also populated so the OR logic between the day fields is preserved. A schedule with a Location is
+// prefixed with the CRON_TZ token.
+//
+// The result is cached when the schedule is parsed or rebuilt so schedules used as map keys or logged frequently do not
+// regenerate it. String never modifies the schedule so it is safe to call concurrently, but as with every other method
+// it is not safe to call while the schedule is being modified. The cache is cleared by the Add methods and regenerated
+// by Rebuild, so Rebuild or NormalizeStrings must be called after the field maps are modified directly.
+func (s *Schedule) String() string {
+	if s.canonical != "" && s.canonicalLocation == s.Location {
+		return s.canonical
+	}
+	return s.canonicalString()
+}
+
+// canonicalString generates the result of String without using the cache.
+func (s *Schedule) canonicalString() string {
+	fields := make([]string, 5)
+	for i := range fields {
+		fields[i] = s.fieldString(i)
+	}
+
+	if s.Location != nil {
+		return cronTZPrefix + s.Location.String() + " " + strings.Join(fields, " ")
+	}
+	return strings.Join(fields, " ")
+}
+
+// fieldString generates the canonical string of the field at index.
+func (s *Schedule) fieldString(index int) string {
+	values := sortMapKeys(s.fieldMapByIndex(index))
+
+	// Occurrences of days of the week are listed after the days of the week.
+	if index == 4 && len(s.NthDaysOfTheWeek) != 0 {
+		nthStr := strings.Join(s.nthWeekdayStrings(), ",")
+		if len(values) == 0 {
+			return nthStr
+		}
+		return s.valuesString(index, values) + "," + nthStr
+	}
+
+	if len(values) == 0 {
+		return "*"
+	}
+
+	return s.valuesString(index, values)
+}
+
+// valuesString generates the canonical string of the sorted non empty _values_ of the field at index.
+func (s *Schedule) valuesString(index int, values []int) string {
+	min, max, err := fieldMinMaxByIndex(index)
+	if err != nil {
+		return "*"
+	}
+
+	if len(values) == max-min+1 {
+		// Day of month without a day of week and every other field can use the wildcard. Using it for a day of week
+		// or a day of month paired with a day of week would cause Parse to clear one of the day fields.
+		if (index != 2 && index != 4) || (index == 2 && !s.hasDayOfWeek()) {
+			return "*"
+		}
+		return fmt.Sprintf("%d-%d", min, max)
+	}
+
+	if step, ok := valueStep(values, min, max); ok {
+		return fmt.Sprintf("*/%d", step)
+	}
+
+	return formatValueList(values)
 }
 
-// ShouldExecute returns true if the schedule should be executed at time _t_.
+// ExpandedFields returns each field of the schedule written as an explicit comma separated list of every value
+// resolved for the field in ascending order. Ranges, steps, and wildcards are never used so the result is a fully
+// expanded form suitable for exact comparison. Occurrences of days of the week specified with the # operator are listed
+// after the days of the week. A field without any values, such as a day field cleared by Parse, is
+// returned as an empty string. Fields are indexed by the cron schedule format as described by AddByIndex.
+func (s *Schedule) ExpandedFields() [5]string {
+	var fields [5]string
+	for i := range fields {
+		values := sortMapKeys(s.fieldMapByIndex(i))
+		valueStrs := make([]string, len(values))
+		for j, value := range values {
+			valueStrs[j] = strconv.Itoa(value)
+		}
+		if i == 4 {
+			valueStrs = append(valueStrs, s.nthWeekdayStrings()...)
+		}
+		fields[i] = strings.Join(valueStrs, ",")
+	}
+	return fields
+}
+
+// Normalize parses the cron schedule _s_ and returns the canonical form generated by Schedule.String. An error is
+// provided if the schedule fails to parse.
+func Normalize(s string) (string, error) {
+	schedule, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return schedule.String(), nil
+}
+
+// Key returns a stable key identifying the values of the schedule, suitable for deduplicating schedules stored in a
+// map. The key is the canonical form generated by String, the same form provided by Normalize, so schedules written
+// differently have the same key whenever they are Equal.
+func (s *Schedule) Key() string {
+	return s.String()
+}
+
+// ShouldExecute returns true if the schedule should be executed at time _t_. Seconds are ignored as schedules only have
+// minute granularity. Times built with a second value of 60, as sometimes reported by external sources during a leap
+// second, are normalized by Go into the following minute and are evaluated as that minute.
 func (s *Schedule) ShouldExecute(t time.Time) bool {
+	t = t.Add(-s.offset)
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
+
 	if _, ok := s.Minutes[t.Minute()]; !ok {
 		return false
 	}
@@ -91,195 +320,1071 @@ func (s *Schedule) ShouldExecute(t time.Time) bool {
 
 	// Per POSIX spec the day of week and day of month are ORed...
 	_, dayOfMonthOK := s.DaysOfMonth[t.Day()]
-	_, dayOfWeekOK := s.DaysOfTheWeek[int(t.Weekday())]
-	if !dayOfWeekOK && !dayOfMonthOK {
+	if !s.dayOfWeekMatches(t) && !dayOfMonthOK {
+		return false
+	}
+
+	return true
+}
+
+// ExplainMatch returns whether the schedule should be executed at time _t_ along with a human readable explanation of
+// each field checked, e.g. "minute 28 ✓, hour 15 ✓, month July ✗ (allowed: August)". Allowed values are listed for each
+// field that does not match. The day of month and day of week are only included when populated as they are ORed.
+func (s *Schedule) ExplainMatch(t time.Time) (bool, string) {
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
+
+	explanations := make([]string, 0, 5)
+	explain := func(name string, value string, ok bool, allowed string) {
+		if ok {
+			explanations = append(explanations, fmt.Sprintf("%s %s ✓", name, value))
+		} else {
+			explanations = append(explanations, fmt.Sprintf("%s %s ✗ (allowed: %s)", name, value, allowed))
+		}
+	}
+
+	_, ok := s.Minutes[t.Minute()]
+	explain("minute", strconv.Itoa(t.Minute()), ok, formatValueList(sortMapKeys(s.Minutes)))
+
+	_, ok = s.Hours[t.Hour()]
+	explain("hour", strconv.Itoa(t.Hour()), ok, formatValueList(sortMapKeys(s.Hours)))
+
+	if len(s.DaysOfMonth) != 0 {
+		_, ok = s.DaysOfMonth[t.Day()]
+		explain("day of month", strconv.Itoa(t.Day()), ok, formatValueList(sortMapKeys(s.DaysOfMonth)))
+	}
+
+	allowedMonths := make([]string, 0, len(s.Months))
+	for _, month := range sortMapKeys(s.Months) {
+		allowedMonths = append(allowedMonths, time.Month(month).String())
+	}
+	_, ok = s.Months[int(t.Month())]
+	explain("month", t.Month().String(), ok, strings.Join(allowedMonths, ","))
+
+	if s.hasDayOfWeek() {
+		allowedDays := make([]string, 0, len(s.DaysOfTheWeek)+len(s.NthDaysOfTheWeek))
+		for _, day := range sortMapKeys(s.DaysOfTheWeek) {
+			allowedDays = append(allowedDays, time.Weekday(day).String())
+		}
+		allowedDays = append(allowedDays, s.nthWeekdayStrings()...)
+		ok = s.dayOfWeekMatches(t)
+		explain("day of week", t.Weekday().String(), ok, strings.Join(allowedDays, ","))
+	}
+
+	return s.ShouldExecute(t), strings.Join(explanations, ", ")
+}
+
+// FilterMatching returns a new slice containing only the times in _times_ that the schedule should be executed at as
+// determined by ShouldExecute. The order of the times is preserved.
+func (s *Schedule) FilterMatching(times []time.Time) []time.Time {
+	matching := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		if s.ShouldExecute(t) {
+			matching = append(matching, t)
+		}
+	}
+	return matching
+}
+
+// FiresOnDay returns the number of times the schedule executes on the day of _date_. The time of day of _date_ is
+// ignored. Zero is returned when the month or day fields exclude the day, otherwise every hour and minute combination
+// is counted.
+func (s *Schedule) FiresOnDay(date time.Time) int {
+	if s.Location != nil {
+		date = date.In(s.Location)
+	}
+
+	if _, ok := s.Months[int(date.Month())]; !ok {
+		return 0
+	}
+
+	if !s.dayMatches(date.Year(), date.Month(), date.Day()) {
+		return 0
+	}
+
+	return len(s.Hours) * len(s.Minutes)
+}
+
+// FireCountInMonth returns the number of times the schedule executes in _month_ of _year_. The actual length of the
+// month is used, so February 29 is only counted in leap years, and the day of month and day of week are ORed. Every
+// hour and minute combination of each matching day is counted without adjusting for daylight saving transitions.
+func (s *Schedule) FireCountInMonth(year int, month time.Month) int {
+	if _, ok := s.Months[int(month)]; !ok {
+		return 0
+	}
+
+	days := 0
+	for day := 1; day <= daysPerMonth(month, year); day++ {
+		if s.dayMatches(year, month, day) {
+			days++
+		}
+	}
+	return days * len(s.Hours) * len(s.Minutes)
+}
+
+// FieldValues returns the sorted values of the field at _index_. The index is determined by the cron schedule format
+// as described by AddByIndex. The slice returned is a copy so modifying it does not alter the schedule. Nil is returned
+// for an invalid index.
+func (s *Schedule) FieldValues(index int) []int {
+	var values []int
+	switch index {
+	case 0:
+		values = s.MinutesSlice
+	case 1:
+		values = s.HoursSlice
+	case 2:
+		values = s.DaysOfMonthSlice
+	case 3:
+		values = s.MonthsSlice
+	case 4:
+		values = s.DaysOfWeekSlice
+	default:
+		return nil
+	}
+
+	return append(make([]int, 0, len(values)), values...)
+}
+
+// FieldCardinality returns the number of values of each field, indexed by the cron schedule format as described by
+// AddByIndex. Occurrences of days of the week specified with the # operator are counted as day of week values and a
+// day field cleared by Parse has no values. Multiplying the counts provides a cheap estimate of the executions a
+// window query would produce.
+func (s *Schedule) FieldCardinality() [5]int {
+	var counts [5]int
+	for i := range counts {
+		counts[i] = len(s.fieldMapByIndex(i))
+	}
+	counts[4] += len(s.NthDaysOfTheWeek)
+	return counts
+}
+
+// MinuteMask returns the minute field as a bitmask where bit i is set if minute i is a value of the field, allowing
+// the minute to be checked with a single bitwise operation.
+func (s *Schedule) MinuteMask() uint64 {
+	var mask uint64
+	for minute := range s.Minutes {
+		if minute >= FieldMinuteMin && minute <= FieldMinuteMax {
+			mask |= 1 << uint(minute)
+		}
+	}
+	return mask
+}
+
+// MinuteHourGrid returns the minutes in ascending order the schedule executes at within each hour of the day, indexed
+// by hour. Hours the schedule does not execute in contain an empty slice. The grid describes a single day, so the day
+// and month fields are not considered.
+func (s *Schedule) MinuteHourGrid() [24][]int {
+	var grid [24][]int
+	minutes := sortMapKeys(s.Minutes)
+	for hour := range grid {
+		if _, ok := s.Hours[hour]; ok {
+			grid[hour] = append(make([]int, 0, len(minutes)), minutes...)
+		} else {
+			grid[hour] = make([]int, 0)
+		}
+	}
+	return grid
+}
+
+// Weekdays returns the days of the week of the schedule as time.Weekday values in ascending order. A day of week field
+// cleared by Parse results in an empty slice.
+func (s *Schedule) Weekdays() []time.Weekday {
+	weekdays := make([]time.Weekday, 0, len(s.DaysOfTheWeek))
+	for _, day := range sortMapKeys(s.DaysOfTheWeek) {
+		weekdays = append(weekdays, time.Weekday(day))
+	}
+	return weekdays
+}
+
+// MonthsTyped returns the months of the schedule as time.Month values in ascending order.
+func (s *Schedule) MonthsTyped() []time.Month {
+	months := make([]time.Month, 0, len(s.Months))
+	for _, month := range sortMapKeys(s.Months) {
+		months = append(months, time.Month(month))
+	}
+	return months
+}
+
+// SingleMinute returns the minute of the schedule and true when the minute field contains exactly one value.
+func (s *Schedule) SingleMinute() (int, bool) {
+	return singleValue(s.Minutes)
+}
+
+// SingleHour returns the hour of the schedule and true when the hour field contains exactly one value.
+func (s *Schedule) SingleHour() (int, bool) {
+	return singleValue(s.Hours)
+}
+
+// SingleDayOfMonth returns the day of month of the schedule and true when the day of month field contains exactly one
+// value.
+func (s *Schedule) SingleDayOfMonth() (int, bool) {
+	return singleValue(s.DaysOfMonth)
+}
+
+// SingleMonth returns the month of the schedule and true when the month field contains exactly one value.
+func (s *Schedule) SingleMonth() (int, bool) {
+	return singleValue(s.Months)
+}
+
+// SingleDayOfTheWeek returns the day of the week of the schedule and true when the day of week field contains exactly
+// one value.
+func (s *Schedule) SingleDayOfTheWeek() (int, bool) {
+	return singleValue(s.DaysOfTheWeek)
+}
+
+// Contains returns true if the field at _index_ includes _value_. The index is determined by the cron schedule format
+// as described by AddByIndex. False is returned for an invalid index. A day field cleared by Parse due to the day of
+// month and day of week logic contains no values.
+func (s *Schedule) Contains(index int, value int) bool {
+	m := s.fieldMapByIndex(index)
+	if m == nil {
+		return false
+	}
+
+	_, ok := m[value]
+	return ok
+}
+
+// Frequency returns a coarse label describing how often the schedule executes. The label is determined by which fields
+// contain every value (full) and which contain a single value (single).
+//
+// - minutely: minute, hour, and month are full and every day is included.
+// - hourly: minute is single while hour and month are full and every day is included.
+// - daily: minute and hour are single, month is full, and every day is included.
+// - weekly: minute, hour, and day of week are single, day of month is unused, and month is full.
+// - monthly: minute, hour, and day of month are single, day of week is unused, and month is full.
+// - yearly: minute, hour, day of month, and month are single and day of week is unused.
+// - irregular: any other schedule.
+//
+// Every day is included when either day field is full.
+func (s *Schedule) Frequency() string {
+	full := func(m map[int]int, min int, max int) bool {
+		return len(m) == max-min+1
+	}
+
+	minutesFull := full(s.Minutes, FieldMinuteMin, FieldMinuteMax)
+	hoursFull := full(s.Hours, FieldHourMin, FieldHourMax)
+	monthsFull := full(s.Months, FieldMonthMin, FieldMonthMax)
+	everyDay := s.includesEveryDay()
+	singleTime := len(s.Minutes) == 1 && len(s.Hours) == 1
+
+	switch {
+	case minutesFull && hoursFull && everyDay && monthsFull:
+		return "minutely"
+	case len(s.Minutes) == 1 && hoursFull && everyDay && monthsFull:
+		return "hourly"
+	case singleTime && everyDay && monthsFull:
+		return "daily"
+	case singleTime && len(s.DaysOfMonth) == 0 && len(s.DaysOfTheWeek) == 1 && len(s.NthDaysOfTheWeek) == 0 && monthsFull:
+		return "weekly"
+	case singleTime && len(s.DaysOfMonth) == 1 && !s.hasDayOfWeek() && monthsFull:
+		return "monthly"
+	case singleTime && len(s.DaysOfMonth) == 1 && !s.hasDayOfWeek() && len(s.Months) == 1:
+		return "yearly"
+	default:
+		return "irregular"
+	}
+}
+
+// Equal returns true if each field of the schedule contains the same values as the same field of _other_ and both
+// are evaluated in the same Location with the same Offset. The original strings of the schedules are not compared.
+// Schedules with the same fields in different locations execute at different instants so are not equal;
+// EqualIgnoringTZ compares the fields alone.
+func (s *Schedule) Equal(other *Schedule) bool {
+	if s.offset != other.offset {
+		return false
+	}
+	if (s.Location == nil) != (other.Location == nil) {
+		return false
+	}
+	if s.Location != nil && s.Location.String() != other.Location.String() {
+		return false
+	}
+
+	return s.EqualIgnoringTZ(other)
+}
+
+// EqualIgnoringTZ returns true if each field of the schedule contains the same values as the same field of _other_
+// regardless of the Location of either schedule. Schedules that are equal ignoring the location execute at the same
+// wall clock times in their own locations.
+func (s *Schedule) EqualIgnoringTZ(other *Schedule) bool {
+	for i := 0; i < 5; i++ {
+		a, b := s.fieldMapByIndex(i), other.fieldMapByIndex(i)
+		if len(a) != len(b) || !isSubset(a, b) {
+			return false
+		}
+	}
+
+	if len(s.NthDaysOfTheWeek) != len(other.NthDaysOfTheWeek) {
+		return false
+	}
+	for _, nth := range s.NthDaysOfTheWeek {
+		if !other.containsNthWeekday(nth) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubsetOf returns true if every value of each field of the schedule is also a value of the same field of _other_,
+// meaning the schedule never executes at a time _other_ does not. As the day of month and day of week are ORed, the
+// day fields are compared individually unless _other_ includes every day. This is conservative: a day of month that
+// _other_ only covers through its day of week on particular dates is not considered a subset.
+func (s *Schedule) IsSubsetOf(other *Schedule) bool {
+	if !isSubset(s.Minutes, other.Minutes) || !isSubset(s.Hours, other.Hours) || !isSubset(s.Months, other.Months) {
 		return false
 	}
 
+	if other.includesEveryDay() {
+		return true
+	}
+
+	if !isSubset(s.DaysOfMonth, other.DaysOfMonth) || !isSubset(s.DaysOfTheWeek, other.DaysOfTheWeek) {
+		return false
+	}
+
+	// An occurrence of a day of the week is covered by the same occurrence or by every occurrence of the day.
+	for _, nth := range s.NthDaysOfTheWeek {
+		if _, ok := other.DaysOfTheWeek[int(nth.Weekday)]; !ok && !other.containsNthWeekday(nth) {
+			return false
+		}
+	}
 	return true
 }
 
-// ShouldExecuteNow is the same as ShouldExecute but uses the current time.
+// IsWildcard returns true if the field at _index_ contains every value allowed for the field, whether written as * or
+// an equivalent such as */1 or 0-59. The index is determined by the cron schedule format as described by AddByIndex.
+// A day field cleared by Parse contains no values so is not a wildcard.
+func (s *Schedule) IsWildcard(index int) bool {
+	min, max, err := fieldMinMaxByIndex(index)
+	if err != nil {
+		return false
+	}
+	return len(s.fieldMapByIndex(index)) == max-min+1
+}
+
+// IsEveryMinute returns true if the schedule executes every minute, allowing callers to skip evaluating the schedule
+// entirely. The minute, hour, and month fields must be wildcards and every day must be included by either day field
+// as they are ORed.
+func (s *Schedule) IsEveryMinute() bool {
+	return s.IsWildcard(0) && s.IsWildcard(1) && s.IsWildcard(3) && (s.IsWildcard(2) || s.IsWildcard(4))
+}
+
+// includesEveryDay returns true if either day field contains every value resulting in every day being included.
+func (s *Schedule) includesEveryDay() bool {
+	return len(s.DaysOfMonth) == FieldDayOfMonthMax-FieldDayOfMonthMin+1 ||
+		len(s.DaysOfTheWeek) == FieldDayOfTheWeekMax-FieldDayOfTheWeekMin+1
+}
+
+// ShouldExecuteNow is the same as ShouldExecute but uses the current time. The current time is provided by the clock
+// configured with WithClock when the schedule was created with NewSchedule.
 func (s *Schedule) ShouldExecuteNow() bool {
-	return s.ShouldExecute(time.Now())
+	return s.ShouldExecute(s.currentTime())
 }
 
-// computeStartValues computes the starting values for generating the closest schedule time for t. If the schedule
-// directly aligns with t then the values related to t would be returned. In general t + 1second is generally provided
-// as the result of t would always be in the past as seconds would be assumed to be zero.
-func (s *Schedule) computeStartValues(t time.Time) (year int, monthIdx int, hourIdx int, minuteIdx int, day int) {
-	tYear := t.Year()
+// ShouldExecuteNowIn is the same as ShouldExecuteNow but evaluates the current time in the location _loc_. A schedule
+// with a Location is always evaluated in its own Location.
+func (s *Schedule) ShouldExecuteNowIn(loc *time.Location) bool {
+	return s.ShouldExecute(s.currentTime().In(loc))
+}
+
+// currentTime returns the current time from the clock of the schedule, falling back to the package clock.
+func (s *Schedule) currentTime() time.Time {
+	if s.clock != nil {
+		return s.clock()
+	}
+	return now()
+}
+
+// location returns the location execution times are generated in.
+func (s *Schedule) location() *time.Location {
+	if s.Location != nil {
+		return s.Location
+	}
+	return time.Local
+}
+
+// cursor is a position from which execution times are generated. The year and day are values while the month, hour,
+// and minute are indexes into the MonthsSlice, HoursSlice, and MinutesSlice of the schedule. The advance methods reset
+// every smaller unit so the cursor always points at the first time of the unit it moved to.
+type cursor struct {
+	year      int
+	monthIdx  int
+	day       int
+	hourIdx   int
+	minuteIdx int
+}
+
+// startOfDay moves the cursor to the first hour and minute of its day.
+func (c *cursor) startOfDay() {
+	c.hourIdx = 0
+	c.minuteIdx = 0
+}
+
+// startOfMonth moves the cursor to the first day, hour, and minute of its month.
+func (c *cursor) startOfMonth() {
+	c.day = 1
+	c.startOfDay()
+}
+
+// nextHour moves the cursor to the first minute of the next hour.
+func (c *cursor) nextHour() {
+	c.hourIdx++
+	c.minuteIdx = 0
+}
+
+// nextDay moves the cursor to the first hour and minute of the next day.
+func (c *cursor) nextDay() {
+	c.day++
+	c.startOfDay()
+}
+
+// nextMonth moves the cursor to the first day, hour, and minute of the next month.
+func (c *cursor) nextMonth() {
+	c.monthIdx++
+	c.startOfMonth()
+}
+
+// nextYear moves the cursor to the first month, day, hour, and minute of the next year.
+func (c *cursor) nextYear() {
+	c.year++
+	c.monthIdx = 0
+	c.startOfMonth()
+}
+
+// computeStartValues computes the starting cursor for generating the closest schedule time for t. If the schedule
+// directly aligns with t then the cursor points at t. In general t truncated to the minute + 1 minute is provided as
+// the result of t would always be in the past as seconds would be assumed to be zero. The cursor may point at a day
+// that does not match the day fields as the generation checks each day itself.
+func (s *Schedule) computeStartValues(t time.Time) cursor {
+	c := cursor{year: t.Year(), day: t.Day()}
 	tMonth := t.Month()
-	tDay := t.Day()
 	tHour := t.Hour()
 	tMinute := t.Minute()
 
-	monthIdx = 0
-	hourIdx = 0
-
 	// Finding what the correct start month should be by looking at all valid months in the schedule.
-	for monthIdx < len(s.MonthsSlice) {
+	for ; c.monthIdx < len(s.MonthsSlice); c.monthIdx++ {
+		if s.MonthsSlice[c.monthIdx] > int(tMonth) {
+			// The month found is larger than the start month so the search starts at the beginning of this month in
+			// the same year.
+			c.startOfMonth()
+			return c
+		}
+
+		if s.MonthsSlice[c.monthIdx] != int(tMonth) {
+			continue
+		}
+
+		// Found the exact month so checking if the day is a good starting point.
+		if s.dayMatches(c.year, tMonth, c.day) {
+			for ; c.hourIdx < len(s.HoursSlice); c.nextHour() {
+				if s.HoursSlice[c.hourIdx] > tHour {
+					// The hour is past the provided hour so the search starts at its first minute.
+					return c
+				}
+
+				if s.HoursSlice[c.hourIdx] == tHour {
+					// The hour is correct so find the next minute. Once every minute of the hour has passed the search
+					// continues with the next hour.
+					for ; c.minuteIdx < len(s.MinutesSlice); c.minuteIdx++ {
+						if s.MinutesSlice[c.minuteIdx] >= tMinute {
+							return c
+						}
+					}
+				}
+			}
+		}
 
-		if s.MonthsSlice[monthIdx] > int(tMonth) {
-			// The month found is now larger than the start month so the new start value would be this month and
-			// the same year. All other field would start at zero.
-			return tYear, monthIdx, 0, 0, 1
+		// The day has no remaining times so trying the next day.
+		c.nextDay()
+		if c.day <= daysPerMonth(tMonth, c.year) {
+			return c
 		}
 
-		if s.MonthsSlice[monthIdx] == int(tMonth) {
-			// Found the exact month so we need to lookup everything else.
+		// The next day loops to a new month which is found by continuing the search.
+		c.startOfMonth()
+	}
 
-			// Validate the day is a good stating point.
-			_, dayOfMonthOK := s.DaysOfMonth[tDay]
-			t := time.Date(tYear, tMonth, tDay, 0, 0, 0, 0, time.Local)
-			_, dayOfWeekOK := s.DaysOfTheWeek[int(t.Weekday())]
+	// The current month, nor a month after the current was found in the current year. Start the search at the beginning
+	// of the next year.
+	c.nextYear()
+	return c
+}
 
-			if dayOfWeekOK || dayOfMonthOK {
+// NextExecutions returns a slice containing of _count_ times when the schedule should execute next. The times are
+// built from wall clock values so they never carry a monotonic clock reading. Any monotonic reading of _t_, such as
+// one from time.Now, is stripped before use so comparisons and durations between _t_ and the results consistently use
+// the wall clock. The times are strictly increasing: a day matching both the day of month and day of week is only
+// generated once, as is a time skipped by a daylight saving transition that normalizes to a time already generated.
+// Such a skipped time is never returned when it normalizes to a time at or before _t_. Every time returned is strictly
+// after the minute of _t_, so when the schedule executes at the minute of _t_, whether _t_ is at the start of the
+// minute or has seconds, the first time returned is the following execution rather than that minute.
+func (s *Schedule) NextExecutions(t time.Time, count int) []time.Time {
+	execTimes, _ := s.nextExecutions(context.Background(), t, count)
+	return execTimes
+}
+
+// NextExecutionsCtx is the same as NextExecutions but stops searching once _ctx_ is done, checking it as each year is
+// scanned. The times found before _ctx_ was done are returned along with the error of _ctx_. An empty slice is returned
+// when _count_ is 0 or less. This prevents a search for a schedule that never executes, or executes rarely, from
+// blocking forever.
+func (s *Schedule) NextExecutionsCtx(ctx context.Context, t time.Time, count int) ([]time.Time, error) {
+	return s.nextExecutions(ctx, t, count)
+}
+
+// nextExecutions generates the times for NextExecutions and NextExecutionsCtx, checking _ctx_ before each year is
+// scanned.
+func (s *Schedule) nextExecutions(ctx context.Context, t time.Time, count int) ([]time.Time, error) {
+	if count <= 0 {
+		return make([]time.Time, 0), nil
+	}
+
+	// execTimes will store all the resulting execution times found.
+	execTimes := make([]time.Time, 0, count)
+
+	// Generating the unshifted times after the unshifted _t_ and shifting them by the offset once found.
+	t = t.Round(0).Add(-s.offset)
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
 
-				// The day of week is valid so process hours.
-				for hourIdx < len(s.HoursSlice) {
+	// Computing the starting position for the generation algorithm. The seconds of _t_ are truncated before moving to
+	// the next minute so the start is always the minute after _t_ rather than depending on the seconds.
+	c := s.computeStartValues(t.Truncate(time.Minute).Add(1 * time.Minute))
+
+	// Generating the next run time until total count is reached. Generation is performed by simply processing the
+	// permutations of the known values. Days are an outlier due to the OR nature of day of the month and day of the week.
+	var numFound = 0
+
+permutation:
+	for numFound <= count {
+		if err := ctx.Err(); err != nil {
+			return s.shiftTimes(execTimes), err
+		}
+
+		// Processing each supported month.
+		for ; c.monthIdx < len(s.MonthsSlice); c.nextMonth() {
+			month := time.Month(s.MonthsSlice[c.monthIdx])
+
+			// Processing the days.
+			daysInMonth := daysPerMonth(month, c.year)
+			for ; c.day <= daysInMonth; c.nextDay() {
+				if !s.dayMatches(c.year, month, c.day) {
+					continue
+				}
+
+				// Processing the hours.
+				for ; c.hourIdx < len(s.HoursSlice); c.nextHour() {
+					hour := s.HoursSlice[c.hourIdx]
+
+					for ; c.minuteIdx < len(s.MinutesSlice); c.minuteIdx++ {
+						minute := s.MinutesSlice[c.minuteIdx]
+
+						// A wall clock time skipped by a daylight saving transition is normalized by time.Date to
+						// a time that may already have been generated or may even be before _t_. Each time is only
+						// generated once and always after _t_.
+						execT := time.Date(c.year, month, c.day, hour, minute, 0, 0, s.location())
+						if !execT.After(t) || len(execTimes) != 0 && !execT.After(execTimes[len(execTimes)-1]) {
+							continue
+						}
+						execTimes = append(execTimes, execT)
+						numFound++
+
+						// Checking if we have the correct number and breaking early if so.  Waiting would result in
+						// more than count returned.
+						if numFound == count {
+							break permutation
+						}
+					}
+				}
+			}
+		}
+
+		// Starting at the first month:day:hour:minute of the next year.
+		c.nextYear()
+	}
+	return s.shiftTimes(execTimes), nil
+}
+
+// Simulate generates a multi line timeline of the next _count_ executions after _from_. Each line contains the time of
+// the execution followed by the duration since the previous execution, or since _from_ for the first execution. For
+// example:
+//    Thu 2020-07-23 10:15 UTC (+15m0s)
+//    Thu 2020-07-23 10:30 UTC (+15m0s)
+// An empty timeline is returned if the schedule can never execute.
+func (s *Schedule) Simulate(from time.Time, count int) string {
+	if count <= 0 || !s.IsSatisfiable() {
+		return ""
+	}
+
+	var timeline strings.Builder
+	previous := from
+	for _, execT := range s.NextExecutions(from, count) {
+		timeline.WriteString(execT.Format("Mon 2006-01-02 15:04 MST"))
+		timeline.WriteString(" (+")
+		timeline.WriteString(execT.Sub(previous).String())
+		timeline.WriteString(")\n")
+		previous = execT
+	}
+	return timeline.String()
+}
+
+// NextExecution returns the next time the schedule should be executed starting from time _t_. It is a convenience
+// method to return the next immediate execution time. It leverages NextExecutions() which should be used if multiple
+// values are needed. Looping on NexExecution is redundant. As with NextExecutions the result carries no monotonic clock
+// reading.
+func (s *Schedule) NextExecution(t time.Time) time.Time {
+	execTimes := s.NextExecutions(t, 1)
+	return execTimes[0]
+}
+
+// Next returns the next time the schedule should be executed after time _t_, the same as NextExecution. It allows a
+// *Schedule to satisfy the Schedule interface of github.com/robfig/cron, which requires only this method, so schedules
+// may be run by its Cron runner. Matching that interface, the zero time is returned if the schedule can never execute.
+func (s *Schedule) Next(t time.Time) time.Time {
+	if !s.IsSatisfiable() {
+		return time.Time{}
+	}
+	return s.NextExecution(t)
+}
+
+// PreviousExecutions returns a slice containing _count_ times when the schedule last executed before time _t_. The
+// times are ordered from the most recent to the oldest. An empty slice is returned if the schedule can never execute.
+func (s *Schedule) PreviousExecutions(t time.Time, count int) []time.Time {
+	if count <= 0 || !s.IsSatisfiable() {
+		return []time.Time{}
+	}
+	execTimes := make([]time.Time, 0, count)
+
+	// Walking in the location executions are created in so the year, month, and day of t are comparable with them. The
+	// unshifted times are generated before the unshifted _t_ and shifted by the offset once found.
+	t = t.Add(-s.offset).In(s.location())
+
+	// Walking the permutations of the known values backwards from t. Values that are after t in the year t falls in
+	// are skipped.
+	tYear := t.Year()
+	tMonth := int(t.Month())
+	tDay := t.Day()
+	for year := tYear; ; year-- {
+		for monthIdx := len(s.MonthsSlice) - 1; monthIdx >= 0; monthIdx-- {
+			month := s.MonthsSlice[monthIdx]
+			if year == tYear && month > tMonth {
+				continue
+			}
+
+			for day := daysPerMonth(time.Month(month), year); day >= 1; day-- {
+				if year == tYear && month == tMonth && day > tDay {
+					continue
+				}
+
+				if !s.dayMatches(year, time.Month(month), day) {
+					continue
+				}
+
+				for hourIdx := len(s.HoursSlice) - 1; hourIdx >= 0; hourIdx-- {
+					for minuteIdx := len(s.MinutesSlice) - 1; minuteIdx >= 0; minuteIdx-- {
+						execT := time.Date(year, time.Month(month), day, s.HoursSlice[hourIdx], s.MinutesSlice[minuteIdx], 0, 0, s.location())
+						if !execT.Before(t) {
+							continue
+						}
+
+						execTimes = append(execTimes, execT)
+						if len(execTimes) == count {
+							return s.shiftTimes(execTimes)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// PreviousExecution returns the most recent time the schedule executed before time _t_. It is a convenience method
+// leveraging PreviousExecutions(). A zero time is returned if the schedule can never execute.
+func (s *Schedule) PreviousExecution(t time.Time) time.Time {
+	execTimes := s.PreviousExecutions(t, 1)
+	if len(execTimes) == 0 {
+		return time.Time{}
+	}
+	return execTimes[0]
+}
+
+// Prev returns the most recent time the schedule executed strictly before time _t_, the same as PreviousExecution. It
+// complements Next for navigating a timeline in either direction and, like Next, returns the zero time if the
+// schedule can never execute.
+func (s *Schedule) Prev(t time.Time) time.Time {
+	if !s.IsSatisfiable() {
+		return time.Time{}
+	}
+	return s.PreviousExecution(t)
+}
+
+// LastExecutions returns a slice containing the _count_ most recent times the schedule executed before time _t_. Unlike
+// PreviousExecutions the times are ordered from the oldest to the most recent. An empty slice is returned if the
+// schedule can never execute.
+func (s *Schedule) LastExecutions(t time.Time, count int) []time.Time {
+	execTimes := s.PreviousExecutions(t, count)
+	for i, j := 0, len(execTimes)-1; i < j; i, j = i+1, j-1 {
+		execTimes[i], execTimes[j] = execTimes[j], execTimes[i]
+	}
+	return execTimes
+}
+
+// dayMatches returns true if the schedule executes on the day provided. The day of month and day of week are ORed.
+func (s *Schedule) dayMatches(year int, month time.Month, day int) bool {
+	if _, ok := s.DaysOfMonth[day]; ok {
+		return true
+	}
+
+	// Only checking the day of week if one has been specified.
+	if !s.hasDayOfWeek() {
+		return false
+	}
+
+	return s.dayOfWeekMatches(time.Date(year, month, day, 0, 0, 0, 0, s.location()))
+}
+
+// NextExecutionIgnoringSeconds returns the next time the schedule should be executed whose minute is strictly after the
+// minute of _t_. The seconds of _t_ are ignored entirely so an execution in the same minute as _t_ is never returned,
+// even when _t_ is partway through that minute. NextExecution returns the first execution strictly after _t_ which, as
+// executions occur at the start of a minute, is the same time. This method makes the minute based contract explicit
+// for callers polling at second granularity.
+func (s *Schedule) NextExecutionIgnoringSeconds(t time.Time) time.Time {
+	return s.NextExecution(t.Truncate(time.Minute))
+}
+
+// FireInstant returns the exact instant the schedule fires within the minute of _matchingMinute_. Schedules have
+// minute granularity and fire at second zero of each matching minute, so the seconds and nanoseconds are zeroed using
+// the wall clock of the location the schedule is evaluated in. The result is the same instant NextExecutions generates
+// for that minute and carries no monotonic clock reading. Whether the schedule matches the minute is not checked.
+func (s *Schedule) FireInstant(matchingMinute time.Time) time.Time {
+	if s.Location != nil {
+		matchingMinute = matchingMinute.In(s.Location)
+	}
+
+	year, month, day := matchingMinute.Date()
+	return time.Date(year, month, day, matchingMinute.Hour(), matchingMinute.Minute(), 0, 0, matchingMinute.Location())
+}
+
+// NextAligned returns the first execution at or after the start of the minute containing _t_. The seconds of _t_ are
+// truncated so a matching minute that _t_ is partway through is returned rather than skipped. The zero time is returned
+// if the schedule can never execute.
+func (s *Schedule) NextAligned(t time.Time) time.Time {
+	if !s.IsSatisfiable() {
+		return time.Time{}
+	}
+
+	aligned := t.Truncate(time.Minute)
+	if s.ShouldExecute(aligned) {
+		return aligned
+	}
+	return s.NextExecution(aligned)
+}
+
+// IsDue returns true if the schedule has an execution after _lastRun_ up to and including _now_ that has not been run.
+// Both boundaries are shifted by _tolerance_ to allow for clock skew and early wake ups: an execution up to _tolerance_
+// after _now_ is due, while one up to _tolerance_ after _lastRun_ is considered covered by that run. A zero _tolerance_
+// checks exactly for an execution after _lastRun_ through _now_.
+func (s *Schedule) IsDue(lastRun time.Time, now time.Time, tolerance time.Duration) bool {
+	if !s.IsSatisfiable() {
+		return false
+	}
+	return !s.NextExecution(lastRun.Add(tolerance)).After(now.Add(tolerance))
+}
+
+// NextExecutionSkip returns the _skipEvery_-th execution after _t_, skipping the executions before it. Starting from an
+// execution and passing each result back in produces every _skipEvery_-th execution of the schedule, such as every
+// other Monday for a weekly schedule with a _skipEvery_ of 2. A _skipEvery_ of 1 is the same as NextExecution. A zero
+// time is returned if _skipEvery_ is less than 1 or the schedule can never execute.
+func (s *Schedule) NextExecutionSkip(t time.Time, skipEvery int) time.Time {
+	if skipEvery < 1 || !s.IsSatisfiable() {
+		return time.Time{}
+	}
+	return s.NextExecutions(t, skipEvery)[skipEvery-1]
+}
+
+// NextExecutionAvoiding returns the next time the schedule should be executed after _t_ whose minute is not in
+// _busyMinutes_. Executions at busy minutes are skipped, moving on to later hours or days as needed. The zero time is
+// returned if every minute of the schedule is busy.
+func (s *Schedule) NextExecutionAvoiding(t time.Time, busyMinutes map[int]bool) time.Time {
+	available := false
+	for minute := range s.Minutes {
+		if !busyMinutes[minute] {
+			available = true
+			break
+		}
+	}
+	if !available || !s.IsSatisfiable() {
+		return time.Time{}
+	}
+
+	next := s.NextExecution(t)
+	for busyMinutes[next.Minute()] {
+		next = s.NextExecution(next)
+	}
+	return next
+}
+
+// NextExecutionOutside returns the next time the schedule should be executed after _t_ that does not fall within the
+// blackout from _blackoutStart_ through _blackoutEnd_ inclusive. When the next execution falls within the blackout the
+// first execution after _blackoutEnd_ is returned instead. The zero time is returned if the schedule can never execute.
+func (s *Schedule) NextExecutionOutside(t time.Time, blackoutStart time.Time, blackoutEnd time.Time) time.Time {
+	if !s.IsSatisfiable() {
+		return time.Time{}
+	}
+
+	next := s.NextExecution(t)
+	if next.Before(blackoutStart) || next.After(blackoutEnd) {
+		return next
+	}
+	return s.NextExecution(blackoutEnd)
+}
 
-					if s.HoursSlice[hourIdx] > tHour {
-						// The hour current index hour is past the provided out so send it along with a reset minute.
-						return tYear, monthIdx, hourIdx, 0, tDay
-					}
+// TimesInNextDuration returns all times the schedule should execute after _from_ up to and including _from_ + _d_. An
+// empty slice is returned if the schedule can never execute.
+func (s *Schedule) TimesInNextDuration(from time.Time, d time.Duration) []time.Time {
+	end := from.Add(d)
+	execTimes := make([]time.Time, 0)
+	if !s.IsSatisfiable() {
+		return execTimes
+	}
 
-					if s.HoursSlice[hourIdx] == tHour {
-						// The hour is correct so find the next minute.
+	for next := s.NextExecution(from); !next.After(end); next = s.NextExecution(next) {
+		execTimes = append(execTimes, next)
+	}
+	return execTimes
+}
 
-						for minuteIdx < len(s.MinutesSlice) {
-							if s.MinutesSlice[minuteIdx] >= tMinute {
-								return tYear, monthIdx, hourIdx, minuteIdx, tDay
-							}
-						}
-					}
+// NextExecutionsUntil returns up to _maxCount_ times the schedule should execute after _from_ and before _until_. The
+// search ends at whichever limit is reached first. An empty slice is returned if the schedule can never execute.
+func (s *Schedule) NextExecutionsUntil(from time.Time, until time.Time, maxCount int) []time.Time {
+	execTimes := make([]time.Time, 0)
+	if maxCount <= 0 || !s.IsSatisfiable() {
+		return execTimes
+	}
 
-					hourIdx++
-				}
-			}
-			// The day of week was not valid so trying the next day.
-			nextDay := tDay + 1
-			if nextDay <= daysPerMonth(time.Month(s.MonthsSlice[monthIdx]), tYear) {
-				return tYear, monthIdx, 0, 0, nextDay
-			}
-			// The next day loops to a new month so doing nothing.
+	for next := s.NextExecution(from); next.Before(until); next = s.NextExecution(next) {
+		execTimes = append(execTimes, next)
+		if len(execTimes) == maxCount {
+			break
 		}
+	}
+	return execTimes
+}
 
-		monthIdx++
+// ExecutionsBetween returns every time the schedule should execute within the half open interval starting at _start_
+// and ending before _end_. An execution exactly at _start_ is included while one exactly at _end_ is not, so adjacent
+// windows never report the same execution. ExecutionsBetweenInclusive also includes an execution exactly at _end_.
+func (s *Schedule) ExecutionsBetween(start time.Time, end time.Time) []time.Time {
+	execTimes := make([]time.Time, 0)
+	if !s.IsSatisfiable() {
+		return execTimes
 	}
-	// The current month, nor a month after the current was found in the current year. Start the search at the beginning
-	// of the next year.
-	return tYear + 1, 0, 0, 0, 1
 
+	// Executions are strictly after the time provided so starting just before _start_ includes it.
+	for next := s.NextExecution(start.Add(-time.Nanosecond)); next.Before(end); next = s.NextExecution(next) {
+		execTimes = append(execTimes, next)
+	}
+	return execTimes
 }
 
-// NextExecutions returns a slice containing of _count_ times when the schedule should execute next.
-func (s *Schedule) NextExecutions(t time.Time, count int) []time.Time {
-	// execTimes will store all the resulting execution times found.
-	execTimes := make([]time.Time, 0, count)
-
-	t.Add(1 * time.Minute)
-	// Computing the starting values for the generation algorithm.
-	year, monthIdx, hourIdx, minuteIdx, day := s.computeStartValues(t.Add(1 * time.Minute))
+// ExecutionsBetweenInclusive returns every time the schedule should execute within the closed interval from _start_
+// through _end_. Unlike ExecutionsBetween an execution exactly at _end_ is included.
+func (s *Schedule) ExecutionsBetweenInclusive(start time.Time, end time.Time) []time.Time {
+	return s.ExecutionsBetween(start, end.Add(time.Nanosecond))
+}
 
-	// Generating the next run time until total count is reached. Generation is performed by simply processing the
-	// permutations of the known values. Days are an outlier due to the OR nature of day of the month and day of the week.
-	var numFound = 0
+// FiresAtLeastOnceBetween returns true if the schedule executes at least once within the half open interval starting
+// at _start_ and ending before _end_, e.g. to verify a daily job actually executes within any given day. Only the
+// first execution from _start_ is generated.
+func (s *Schedule) FiresAtLeastOnceBetween(start time.Time, end time.Time) bool {
+	if !s.IsSatisfiable() {
+		return false
+	}
+	return s.NextExecution(start.Add(-time.Nanosecond)).Before(end)
+}
 
-permutation:
-	for numFound <= count {
+// WeekdayDistribution returns the number of executions within the half open interval starting at _start_ and ending
+// before _end_ on each day of the week, indexed by time.Weekday. Executions are counted on the weekday of the location
+// they are generated in.
+func (s *Schedule) WeekdayDistribution(start time.Time, end time.Time) [7]int {
+	var counts [7]int
+	for _, execT := range s.ExecutionsBetween(start, end) {
+		counts[execT.Weekday()]++
+	}
+	return counts
+}
 
-		// Processing each supported month.
-		for monthIdx < len(s.MonthsSlice) {
-			month := s.MonthsSlice[monthIdx]
+// HasOverlap returns true if a job taking _jobDuration_ would still be running when the schedule executes again, i.e.
+// any two consecutive executions of the next _samples_ executions after _from_ are closer together than
+// _jobDuration_. For example every minute overlaps with a job taking 90 seconds. False is returned when fewer than two
+// executions are sampled or the schedule can never execute.
+func (s *Schedule) HasOverlap(jobDuration time.Duration, from time.Time, samples int) bool {
+	if samples < 2 || !s.IsSatisfiable() {
+		return false
+	}
 
-			// Processing the days.
-			daysInMonth := daysPerMonth(time.Month(month), year)
-			for day <= daysInMonth {
+	execTimes := s.NextExecutions(from, samples)
+	for i := 1; i < len(execTimes); i++ {
+		if execTimes[i].Sub(execTimes[i-1]) < jobDuration {
+			return true
+		}
+	}
+	return false
+}
 
-				_, dayOfMonthOK := s.DaysOfMonth[day]
+// LongestGap returns the longest duration between consecutive executions within the half open interval starting at
+// _start_ and ending before _end_, revealing periods the schedule leaves uncovered such as the weekend of a weekday
+// schedule. The time from _start_ to the first execution and from the last execution to _end_ is not considered. Zero
+// is returned when fewer than two executions occur within the interval.
+func (s *Schedule) LongestGap(start time.Time, end time.Time) time.Duration {
+	var longest time.Duration
+	execTimes := s.ExecutionsBetween(start, end)
+	for i := 1; i < len(execTimes); i++ {
+		if gap := execTimes[i].Sub(execTimes[i-1]); gap > longest {
+			longest = gap
+		}
+	}
+	return longest
+}
 
-				dayOfWeekOK := false
-				if len(s.DaysOfTheWeek) != 0 {
-					// Only checking DaysOfTheWeek if one has been specified. Otherwise we assume any day is okay.
-					t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
-					_, dayOfWeekOK = s.DaysOfTheWeek[int(t.Weekday())]
-				}
+// OverlapsWithin returns true if the schedule and _other_ both execute at the same minute at or after _start_ and
+// before _end_. The executions of both schedules within the window are walked in order and compared as instants so
+// schedules evaluated in different locations are compared correctly.
+func (s *Schedule) OverlapsWithin(other *Schedule, start time.Time, end time.Time) bool {
+	if !s.IsSatisfiable() || !other.IsSatisfiable() {
+		return false
+	}
 
-				if dayOfMonthOK || dayOfWeekOK {
-					// Processing the hours.
-					for hourIdx < len(s.HoursSlice) {
-						hour := s.HoursSlice[hourIdx]
+	// Executions are strictly after the time provided so starting just before _start_ includes it.
+	from := start.Add(-time.Nanosecond)
+	a := s.NextExecution(from)
+	b := other.NextExecution(from)
+	for a.Before(end) && b.Before(end) {
+		switch {
+		case a.Equal(b):
+			return true
+		case a.Before(b):
+			a = s.NextExecution(b.Add(-time.Nanosecond))
+		default:
+			b = other.NextExecution(a.Add(-time.Nanosecond))
+		}
+	}
+	return false
+}
 
-						for minuteIdx < len(s.MinutesSlice) {
-							minute := s.MinutesSlice[minuteIdx]
+// NextExecutionsBucketed returns _count_ distinct buckets containing the next executions after _t_. Each execution is
+// truncated with time.Truncate to a multiple of _bucket_ and executions falling in the same bucket are reported once.
+// As with time.Truncate buckets are aligned to the zero time rather than the location of the executions. An empty slice
+// is returned if the schedule can never execute.
+func (s *Schedule) NextExecutionsBucketed(t time.Time, count int, bucket time.Duration) []time.Time {
+	buckets := make([]time.Time, 0, count)
+	if count <= 0 || !s.IsSatisfiable() {
+		return buckets
+	}
 
-							execT := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.Local)
-							execTimes = append(execTimes, execT)
-							numFound++
+	next := t
+	for {
+		for _, execT := range s.NextExecutions(next, count) {
+			next = execT
 
-							// Checking if we have the correct number and breaking early if so.  Waiting would result in
-							// more than count returned.
-							if numFound == count {
-								break permutation
-							}
+			truncated := execT.Truncate(bucket)
+			if len(buckets) != 0 && buckets[len(buckets)-1].Equal(truncated) {
+				continue
+			}
 
-							minuteIdx++
-						}
+			buckets = append(buckets, truncated)
+			if len(buckets) == count {
+				return buckets
+			}
+		}
+	}
+}
 
-						minuteIdx = 0
-						hourIdx++
-					}
-				}
+// NextBusinessDayExecution returns the next time the schedule should be executed starting from time _t_ that falls on
+// Monday through Friday. Executions on Saturday or Sunday are skipped regardless of the schedule's day of week field.
+// A zero time is returned if the schedule can never execute or can never execute on a weekday.
+func (s *Schedule) NextBusinessDayExecution(t time.Time) time.Time {
+	if !s.IsSatisfiable() {
+		return time.Time{}
+	}
 
-				hourIdx = 0
-				minuteIdx = 0
-				day++
+	// Without a day of month the schedule is limited to the days of the week which may all be weekend days.
+	if len(s.DaysOfMonth) == 0 {
+		weekdayFound := false
+		for day := range s.DaysOfTheWeek {
+			if day >= int(time.Monday) && day <= int(time.Friday) {
+				weekdayFound = true
+				break
+			}
+		}
+		for _, nth := range s.NthDaysOfTheWeek {
+			if nth.Weekday >= time.Monday && nth.Weekday <= time.Friday {
+				weekdayFound = true
+				break
 			}
+		}
 
-			// Starting at the first hour:minute:day of the next month.
-			day = 1
-			hourIdx = 0
-			minuteIdx = 0
-			monthIdx++
+		if !weekdayFound {
+			return time.Time{}
 		}
+	}
 
-		// Starting at the next month:hour:minute:day of the next year.
-		monthIdx = 0
-		day = 1
-		hourIdx = 0
-		minuteIdx = 0
-		year++
+	next := s.NextExecution(t)
+	for next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+		// Jumping to the start of the following Monday rather than walking each weekend execution.
+		daysUntilMonday := 1
+		if next.Weekday() == time.Saturday {
+			daysUntilMonday = 2
+		}
+		monday := time.Date(next.Year(), next.Month(), next.Day()+daysUntilMonday, 0, 0, 0, 0, next.Location())
+		next = s.NextExecution(monday.Add(-1 * time.Minute))
 	}
-	return execTimes
 
+	return next
 }
 
-// NextExecution returns the next time the schedule should be executed starting from time _t_. It is a convenience
-// method to return the next immediate execution time. It leverages NextExecutions() which should be used if multiple
-// values are needed. Looping on NexExecution is redundant.
-func (s *Schedule) NextExecution(t time.Time) time.Time {
-	execTimes := s.NextExecutions(t, 1)
-	return execTimes[0]
-}
+// monthDays contains the number of days in each month of a non leap year indexed by time.Month.
+var monthDays = [13]int{0, 31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
 
 // daysPerMonth returns the number of days in the month for the year specified.
 func daysPerMonth(month time.Month, year int) int {
-	switch month {
-	case time.January, time.March, time.May, time.July, time.August, time.October, time.December:
-		return 31
-	case time.April, time.June, time.September, time.November:
-		return 30
-	case time.February:
-		leapTime := time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
-		if leapTime.YearDay() > 365 {
-			return 29
-		} else {
-			return 28
-		}
-	default:
+	if month < time.January || month > time.December {
 		panic("unknown month")
 	}
+
+	if month == time.February && isLeapYear(year) {
+		return 29
+	}
+	return monthDays[month]
+}
+
+// isLeapYear returns true if the year is a leap year in the Gregorian calendar.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// singleValue returns the only key of the map and true if the map has exactly one key.
+func singleValue(m map[int]int) (int, bool) {
+	if len(m) != 1 {
+		return 0, false
+	}
+
+	for k := range m {
+		return k, true
+	}
+	return 0, false
+}
+
+// isSubset returns true if every key of sub is a key of super.
+func isSubset(sub map[int]int, super map[int]int) bool {
+	for k := range sub {
+		if _, ok := super[k]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
 // sortMapKeys sorts the keys of an int keyed map and returns a slice of the sorted keys.
@@ -294,6 +1399,7 @@ func sortMapKeys(m map[int]int) []int {
 
 // AddMinutes adds the minutes listed to the schedule. Invalid values will be ignored.
 func (s *Schedule) AddMinutes(minutes []int) {
+	s.canonical = ""
 	for _, i := range minutes {
 		if i < FieldMinuteMin || i > FieldMinuteMax {
 			continue
@@ -309,6 +1415,7 @@ func (s *Schedule) AddMinutes(minutes []int) {
 
 // AddHours adds the hours listed to the schedule. Invalid values will be ignored.
 func (s *Schedule) AddHours(hours []int) {
+	s.canonical = ""
 	for _, i := range hours {
 		if i < FieldHourMin || i > FieldHourMax {
 			continue
@@ -324,6 +1431,7 @@ func (s *Schedule) AddHours(hours []int) {
 
 // AddDaysOfMonth adds the days of the month listed to the schedule. Invalid values will be ignored.
 func (s *Schedule) AddDaysOfMonth(daysOfMonth []int) {
+	s.canonical = ""
 	for _, i := range daysOfMonth {
 		if i < FieldDayOfMonthMin || i > FieldDayOfMonthMax {
 			continue
@@ -339,6 +1447,7 @@ func (s *Schedule) AddDaysOfMonth(daysOfMonth []int) {
 
 // AddMonths adds the months listed to the schedule. Invalid values will be ignored.
 func (s *Schedule) AddMonths(months []int) {
+	s.canonical = ""
 	for _, i := range months {
 		if i < FieldMonthMin || i > FieldMonthMax {
 			continue
@@ -354,6 +1463,7 @@ func (s *Schedule) AddMonths(months []int) {
 
 // AddDaysOfTheWeek adds the days of the week listed to the schedule. Invalid values will be ignored.
 func (s *Schedule) AddDaysOfTheWeek(daysOfTheWeek []int) {
+	s.canonical = ""
 	for _, i := range daysOfTheWeek {
 		if i < FieldDayOfTheWeekMin || i > FieldDayOfTheWeekMax {
 			continue
@@ -402,9 +1512,15 @@ func (s *Schedule) addFieldStrByIndex(fieldStr string, index int) {
 	}
 }
 
-// emptySchedule generates an empty schedule.
-func emptySchedule() Schedule {
-	return Schedule{
+// EmptySchedule generates an empty schedule. Values may be added with the Add methods followed by a call to Rebuild.
+// The storage of schedules released with Release is reused when available.
+func EmptySchedule() Schedule {
+	return *schedulePool.Get().(*Schedule)
+}
+
+// newEmptySchedule allocates an empty schedule.
+func newEmptySchedule() *Schedule {
+	return &Schedule{
 		Minutes:          make(map[int]int),
 		MinutesStr:       make([]string, 0, 0),
 		MinutesSlice:     make([]int, 0, 0),
@@ -431,12 +1547,21 @@ func emptySchedule() Schedule {
 //
 // - Only supports scheduling including all 5 fields separated by a single space.
 // - Per UNIX spec, utilizes an OR when both day_of_week and day_of_month are specified as anything but *.
-// - Text version of days, e.g. SUN-SAT, are _not_ currently supported.
-// - Text versions of months, e.g. JAN-DEC, are _not_ currently supported.
-// - Predefined schedules, e.g @yearly are _not_ supported.
+// - _Does_ support the names SUN-SAT for days of the week and JAN-DEC for months matched case insensitively. Names may
+// be used anywhere a number may, e.g. MON-FRI or JAN/2.
+// - _Does_ support the predefined schedules @yearly, @annually, @monthly, @weekly, @daily, @midnight, and @hourly
+// matched case insensitively.
+// - _Does_ support @every <duration> for durations that are a whole number of minutes below an hour or a whole number
+// of hours up to a day. As the steps restart each hour or day, durations that do not evenly divide it are approximated.
 // - Years are not supported.
-// - Unsupported non-standard characters include [L, W, #, ?]
+// - _Does_ support a leading CRON_TZ=<location> token specifying the location the schedule is evaluated in.
+// - Unsupported non-standard characters include [L, W, ?]
+// - _Does_ support # in the day of week field, e.g. 5#1. Occurrences are counted from the first of the month so 5#1 is
+// the first Friday of the month regardless of which week it falls in, see NthWeekday.
+// - Day of week accepts 7 as Sunday. It is the end of the field when generating ranges and intervals so 1/2 includes
+// Sunday.
 // - _Does_ support / for intervals. Specifically the job will increment by the value of b in a/b starting with a.
+// - ~ for a random value within a range, e.g. 0~30, is only supported by ParseRandomized.
 //
 // Day Of Month / Day Of Week Logic Table
 //
@@ -448,14 +1573,171 @@ func emptySchedule() Schedule {
 // |Non * Value | Non * Value |All value that match Day Of Month or Day Of Year. Note: If * is included in either it   |
 // |            |             |can include all days and make the other irrelevant.                                     |
 func Parse(s string) (Schedule, error) {
+	return ParseWithOptions(s, ParseOptions{})
+}
+
+// ParseOrDefault parses the cron schedule _s_ the same as Parse, parsing _fallback_ instead if _s_ fails to parse. It
+// simplifies loading schedules from configuration that has a known good default. As the fallback is expected to be
+// valid it panics if _fallback_ also fails to parse.
+func ParseOrDefault(s string, fallback string) Schedule {
+	schedule, err := Parse(s)
+	if err == nil {
+		return schedule
+	}
+
+	schedule, err = Parse(fallback)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse fallback schedule %s: %s", fallback, err))
+	}
+	return schedule
+}
+
+// AtTime generates a schedule executing at the minute, hour, day of month, and month of _t_ every year, i.e. the cron
+// schedule "{minute} {hour} {day} {month} *". The values are taken from _t_ in its own location.
+func AtTime(t time.Time) Schedule {
+	schedule, err := Parse(fmt.Sprintf("%d %d %d %d *", t.Minute(), t.Hour(), t.Day(), int(t.Month())))
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse schedule generated from time %v: %s", t, err))
+	}
+	return schedule
+}
+
+// namedFields contains the index of each field by the key used by ParseNamed.
+var namedFields = map[string]int{
+	"minute": 0,
+	"hour":   1,
+	"dom":    2,
+	"month":  3,
+	"dow":    4,
+}
+
+// ParseNamed parses a schedule written as space separated key=value pairs, e.g. minute=0 hour=22 dow=1-5, rather than
+// positional fields. The keys are minute, hour, dom, month, and dow and may be written in any order. Any field not
+// specified is *. Each value is parsed the same as the field of the same position by Parse.
+func ParseNamed(s string) (Schedule, error) {
+	fields := []string{"*", "*", "*", "*", "*"}
+	specified := make(map[string]bool)
+
+	for _, pair := range strings.Fields(s) {
+		tokens := strings.SplitN(pair, "=", 2)
+		if len(tokens) != 2 || tokens[1] == "" {
+			return EmptySchedule(), fmt.Errorf("expected key=value but received [%s]", pair)
+		}
+
+		key := strings.ToLower(tokens[0])
+		index, ok := namedFields[key]
+		if !ok {
+			return EmptySchedule(), fmt.Errorf("unknown field [%s], expected one of minute, hour, dom, month, or dow", tokens[0])
+		}
+		if specified[key] {
+			return EmptySchedule(), fmt.Errorf("field %s specified more than once", key)
+		}
+		specified[key] = true
+		fields[index] = tokens[1]
+	}
+
+	return Parse(strings.Join(fields, " "))
+}
+
+// ParseWithOptions is the same as Parse but allows the parsing to be configured by _opts_.
+func ParseWithOptions(s string, opts ParseOptions) (Schedule, error) {
+	schedule, _, err := parseDetailed(s, opts)
+	return schedule, err
+}
+
+// parseDetailed parses the cron schedule _s_ as configured by _opts_ providing the schedule along with details of how
+// each field was specified.
+func parseDetailed(s string, opts ParseOptions) (Schedule, ParseInfo, error) {
+	var info ParseInfo
+
+	if opts.FieldOrder != nil {
+		if err := validateFieldOrder(opts.FieldOrder); err != nil {
+			return EmptySchedule(), info, err
+		}
+	}
+
+	// A leading CRON_TZ token specifies the location the rest of the schedule is evaluated in.
+	if strings.HasPrefix(strings.TrimSpace(s), cronTZPrefix) {
+		tokens := strings.SplitN(strings.TrimSpace(s), " ", 2)
+		name := strings.TrimPrefix(tokens[0], cronTZPrefix)
+		if name == "" {
+			return EmptySchedule(), info, fmt.Errorf("received empty CRON_TZ location")
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return EmptySchedule(), info, fmt.Errorf("failed to load CRON_TZ location [%s]: %s", name, err)
+		}
+
+		remaining := ""
+		if len(tokens) == 2 {
+			remaining = tokens[1]
+		}
+
+		schedule, info, err := parseDetailed(remaining, opts)
+		schedule.ScheduleStr = strings.TrimSpace(s)
+		schedule.Location = loc
+		if err == nil {
+			schedule.cacheString()
+		}
+		return schedule, info, err
+	}
+
+	// Nicknames are converted to the equivalent schedule and parsed as such. They are matched case insensitively.
+	if strings.HasPrefix(strings.TrimSpace(s), "@") {
+		nickname := strings.ToLower(strings.TrimSpace(s))
+
+		var expr string
+		if strings.HasPrefix(nickname, everyPrefix) {
+			var err error
+			expr, err = everyExpression(nickname)
+			if err != nil {
+				return EmptySchedule(), info, err
+			}
+		} else {
+			var ok bool
+			expr, ok = nicknames[nickname]
+			if !ok {
+				return EmptySchedule(), info, fmt.Errorf("unknown nickname [%s]", strings.TrimSpace(s))
+			}
+		}
+
+		// The expressions are written using the POSIX day of week numbering and the standard field order.
+		nicknameOpts := opts
+		nicknameOpts.DayOfWeek = DayOfWeekPOSIX
+		nicknameOpts.FieldOrder = nil
+
+		schedule, info, err := parseDetailed(expr, nicknameOpts)
+		schedule.ScheduleStr = strings.TrimSpace(s)
+		return schedule, info, err
+	}
+
 	// Building the empty schedule that will be filled as parsing is completed.
-	schedule := emptySchedule()
+	schedule := EmptySchedule()
 	schedule.ScheduleStr = strings.TrimSpace(s)
 
 	// Split the string by spaces to obtain each field. Expecting exactly 5 fields.
 	fields := strings.Split(schedule.ScheduleStr, " ")
 	if len(fields) != 5 {
-		return schedule, fmt.Errorf("schedule should have 5 fields but found %d", len(fields))
+		countErr := &FieldCountError{Got: len(fields), Want: 5}
+
+		// A list split apart by spaces leaves a field starting or ending with a comma.
+		for _, field := range fields {
+			if strings.HasPrefix(field, ",") || strings.HasSuffix(field, ",") {
+				countErr.Hint = "list values must not contain spaces"
+				break
+			}
+		}
+		return schedule, info, countErr
+	}
+
+	// Moving each field to the position of its index so the rest of parsing works with the standard order.
+	if opts.FieldOrder != nil {
+		ordered := make([]string, len(fields))
+		for pos, index := range opts.FieldOrder {
+			ordered[index] = fields[pos]
+		}
+		fields = ordered
 	}
 
 	// Process each field of the schedule working left to right so index 0 will be the minute while index 4 will be the
@@ -464,24 +1746,87 @@ func Parse(s string) (Schedule, error) {
 
 		// Checking for any empty values to prevent double spaces from being including in the entry.
 		if field == "" {
-			return schedule, fmt.Errorf("received empty value for field %s", fieldNameByIndex(i))
+			return schedule, info, fmt.Errorf("received empty value for field %s", fieldNameByIndex(i))
 		}
 
 		// Retrieving the min and max values for the current field which will be used to process the values
 		// of the field.
 		min, max, err := fieldMinMaxByIndex(i)
 		if err != nil {
-			return schedule, fmt.Errorf("failed to get min and max value for field %s: %s", fieldNameByIndex(i), err)
+			return schedule, info, fmt.Errorf("failed to get min and max value for field %s: %s", fieldNameByIndex(i), err)
+		}
+		if i == 4 {
+			min, max = opts.DayOfWeek.minMax()
+		}
+
+		// The numeric only grammar allows a single wildcard or number per field.
+		if opts.NumericOnly && field != "*" && !isNumeric(field) {
+			return schedule, info, fmt.Errorf("failed to parse %s field with value of %s: only * or a single number is allowed", fieldNameByIndex(i), field)
+		}
+
+		// Rejecting oversized lists before splitting them.
+		if segments := strings.Count(field, ",") + 1; segments > opts.maxListSegments() {
+			return schedule, info, fmt.Errorf("failed to parse %s field: %d values exceeds the maximum of %d", fieldNameByIndex(i), segments, opts.maxListSegments())
 		}
 
 		// Processing every value found in the field. This is specifically needed due to the multi value option
 		// on fields.
-		for _, value := range strings.Split(field, ",") {
+		values := strings.Split(field, ",")
+		if len(values) > 1 {
+			info.Fields[i].Kind = FieldKindList
+		}
+		for _, value := range values {
 			schedule.addFieldStrByIndex(value, i)
 
-			fieldValues, err := parseFieldValue(value, min, max)
+			// Month and day of week names are parsed as the numbers they represent.
+			value = translateNames(value, i, opts.DayOfWeek)
+
+			// Random values are replaced by the value chosen and parsed as such.
+			if strings.Contains(value, "~") {
+				chosen, err := randomValue(value, min, max, opts.Random)
+				if err != nil {
+					return schedule, info, fmt.Errorf("failed to parse %s field with value of %s: %s", fieldNameByIndex(i), value, err)
+				}
+				value = chosen
+			}
+
+			if opts.Strict {
+				if step, ok := tokenStep(value); ok && step > max {
+					return schedule, info, fmt.Errorf("failed to parse %s field with value of %s: step %d exceeds field maximum %d", fieldNameByIndex(i), value, step, max)
+				}
+			}
+
+			if err := checkSpecialTokens(value, i, len(values)); err != nil {
+				return schedule, info, fmt.Errorf("failed to parse %s field with value of %s: %s", fieldNameByIndex(i), value, err)
+			}
+
+			// Occurrences of a day of the week are stored separately from the days of the week.
+			if i == 4 && strings.Contains(value, "#") {
+				nth, err := parseNthWeekday(value, min, max, opts.DayOfWeek)
+				if err != nil {
+					return schedule, info, fmt.Errorf("failed to parse %s field with value of %s: %s", fieldNameByIndex(i), value, err)
+				}
+				if len(values) == 1 {
+					info.Fields[i].Kind = FieldKindSingle
+				}
+
+				schedule.AddNthDaysOfTheWeek([]NthWeekday{nth})
+				continue
+			}
+
+			fieldValues, kind, err := parseFieldValue(value, min, max)
 			if err != nil {
-				return schedule, fmt.Errorf("failed to parse %s field with value of %s: %s", fieldNameByIndex(i), value, err)
+				return schedule, info, fmt.Errorf("failed to parse %s field with value of %s: %s", fieldNameByIndex(i), value, err)
+			}
+			if len(values) == 1 {
+				info.Fields[i].Kind = kind
+			}
+
+			// Day of week values are stored using the POSIX numbering regardless of the convention parsed.
+			if i == 4 {
+				for j := range fieldValues {
+					fieldValues[j] = opts.DayOfWeek.toPOSIX(fieldValues[j])
+				}
 			}
 
 			schedule.AddByIndex(fieldValues, i)
@@ -498,17 +1843,153 @@ func Parse(s string) (Schedule, error) {
 	//
 	// NOTE: multi-value fields and interval fields containing * are undefined.
 	if fields[2] == "*" && fields[4] == "*" {
-		schedule.DaysOfTheWeek = make(map[int]int)
+		schedule.DaysOfTheWeek = clearMap(schedule.DaysOfTheWeek)
+		schedule.DaysOfTheWeekCleared = true
 	}
 	if fields[2] == "*" && fields[4] != "*" {
-		schedule.DaysOfMonth = make(map[int]int)
+		schedule.DaysOfMonth = clearMap(schedule.DaysOfMonth)
+		schedule.DaysOfMonthCleared = true
 	}
 	if fields[2] != "*" && fields[4] == "*" {
-		schedule.DaysOfTheWeek = make(map[int]int)
+		schedule.DaysOfTheWeek = clearMap(schedule.DaysOfTheWeek)
+		schedule.DaysOfTheWeekCleared = true
 	}
 
 	schedule.buildSlices()
-	return schedule, nil
+	for i := range info.Fields {
+		info.Fields[i].Count = len(schedule.fieldMapByIndex(i))
+	}
+	info.Fields[4].Count += len(schedule.NthDaysOfTheWeek)
+	return schedule, info, nil
+}
+
+// Merge adds every value of each field of _other_ to the same field of the schedule and rebuilds the value slices. The
+// fields are merged independently so the result may execute at combinations of values that neither schedule did on its
+// own. The field strings are appended while ScheduleStr is left unchanged.
+func (s *Schedule) Merge(other *Schedule) {
+	for i := 0; i < 5; i++ {
+		s.AddByIndex(sortMapKeys(other.fieldMapByIndex(i)), i)
+	}
+
+	s.MinutesStr = append(s.MinutesStr, other.MinutesStr...)
+	s.HoursStr = append(s.HoursStr, other.HoursStr...)
+	s.DaysOfMonthStr = append(s.DaysOfMonthStr, other.DaysOfMonthStr...)
+	s.MonthsStr = append(s.MonthsStr, other.MonthsStr...)
+	s.DaysOfTheWeekStr = append(s.DaysOfTheWeekStr, other.DaysOfTheWeekStr...)
+	s.AddNthDaysOfTheWeek(other.NthDaysOfTheWeek)
+
+	s.buildSlices()
+}
+
+// RestrictHours returns a copy of the schedule that only executes from _startHour_ through _endHour_ inclusive. The
+// hours of the copy are the hours of the schedule within the range, so a range that excludes every hour results in a
+// schedule that never executes. The strings of the copy are regenerated by NormalizeStrings to reflect the restricted
+// hours while the schedule itself is left unchanged.
+func (s *Schedule) RestrictHours(startHour int, endHour int) *Schedule {
+	restricted := s.clone()
+	for hour := range restricted.Hours {
+		if hour < startHour || hour > endHour {
+			delete(restricted.Hours, hour)
+		}
+	}
+
+	restricted.NormalizeStrings()
+	return restricted
+}
+
+// clone returns a deep copy of the schedule.
+func (s *Schedule) clone() *Schedule {
+	c := newEmptySchedule()
+	for i := 0; i < 5; i++ {
+		dst := c.fieldMapByIndex(i)
+		for k, v := range s.fieldMapByIndex(i) {
+			dst[k] = v
+		}
+	}
+
+	c.MinutesStr = append(c.MinutesStr, s.MinutesStr...)
+	c.HoursStr = append(c.HoursStr, s.HoursStr...)
+	c.DaysOfMonthStr = append(c.DaysOfMonthStr, s.DaysOfMonthStr...)
+	c.MonthsStr = append(c.MonthsStr, s.MonthsStr...)
+	c.DaysOfTheWeekStr = append(c.DaysOfTheWeekStr, s.DaysOfTheWeekStr...)
+	c.NthDaysOfTheWeek = append(c.NthDaysOfTheWeek, s.NthDaysOfTheWeek...)
+
+	c.DaysOfMonthCleared = s.DaysOfMonthCleared
+	c.DaysOfTheWeekCleared = s.DaysOfTheWeekCleared
+	c.ScheduleStr = s.ScheduleStr
+	c.Location = s.Location
+	c.clock = s.clock
+	c.offset = s.offset
+
+	c.buildSlices()
+	return c
+}
+
+// Rebuild regenerates the sorted value slices of each field along with the cached result of String. It must be called
+// after values are added with the Add methods as execution times are generated from the slices.
+func (s *Schedule) Rebuild() {
+	s.buildSlices()
+}
+
+// NormalizeStrings regenerates the field strings and ScheduleStr from the values resolved for each field so
+// PrettyString and ScheduleStr always reflect the values the schedule executes on. Each field string is replaced by the
+// single canonical field generated for String and the value slices are rebuilt. It should be called after the field
+// maps are modified directly or values are added with the Add methods.
+func (s *Schedule) NormalizeStrings() {
+	s.buildSlices()
+	s.MinutesStr = []string{s.fieldString(0)}
+	s.HoursStr = []string{s.fieldString(1)}
+	s.DaysOfMonthStr = []string{s.fieldString(2)}
+	s.MonthsStr = []string{s.fieldString(3)}
+	s.DaysOfTheWeekStr = []string{s.fieldString(4)}
+	s.ScheduleStr = s.String()
+}
+
+// Crontab generates a crontab line executing _command_ on the schedule. The schedule portion is generated by String.
+func (s *Schedule) Crontab(command string) string {
+	return s.String() + " " + command
+}
+
+// cronTZPrefix is the prefix of the token specifying the location of a schedule.
+const cronTZPrefix = "CRON_TZ="
+
+// nicknames contains the schedule of each predefined schedule nickname.
+var nicknames = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// everyPrefix is the prefix of the @every nickname.
+const everyPrefix = "@every "
+
+// everyExpression converts the lower cased @every nickname _s_ into a cron schedule that steps by the duration
+// provided. Only durations that are whole minutes below an hour or whole hours up to a day map onto the cron fields.
+func everyExpression(s string) (string, error) {
+	durationStr := strings.TrimSpace(s[len(everyPrefix):])
+	d, err := time.ParseDuration(durationStr)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse @every duration [%s]: %s", durationStr, err)
+	}
+
+	switch {
+	case d <= 0:
+		return "", fmt.Errorf("@every duration [%s] must be positive", durationStr)
+	case d%time.Minute != 0:
+		return "", fmt.Errorf("@every duration [%s] is not a whole number of minutes", durationStr)
+	case d < time.Hour:
+		return fmt.Sprintf("*/%d * * * *", d/time.Minute), nil
+	case d%time.Hour == 0 && d < 24*time.Hour:
+		return fmt.Sprintf("0 */%d * * *", d/time.Hour), nil
+	case d == 24*time.Hour:
+		return "0 0 * * *", nil
+	default:
+		return "", fmt.Errorf("@every duration [%s] cannot be represented by minute or hour steps", durationStr)
+	}
 }
 
 // buildSlices creates a sorted slice of the values for each field.
@@ -518,18 +1999,53 @@ func (s *Schedule) buildSlices() {
 	s.DaysOfMonthSlice = sortMapKeys(s.DaysOfMonth)
 	s.MonthsSlice = sortMapKeys(s.Months)
 	s.DaysOfWeekSlice = sortMapKeys(s.DaysOfTheWeek)
+	s.cacheString()
+}
+
+// cacheString caches the result of String for the current location. It must be called again after the location is
+// changed for String to use the cache.
+func (s *Schedule) cacheString() {
+	s.canonical = s.canonicalString()
+	s.canonicalLocation = s.Location
+}
+
+// ParseFieldValueOrdered parses every comma separated value of the field _field_ and returns the values encompassed
+// in the order they were written. Values within a single range or interval are in ascending order and values already
+// encompassed by an earlier segment are dropped, so 3,2,4,5 provides [3 2 4 5] while 5,1-6 provides [5 1 2 3 4 6].
+// Schedules match regardless of order; this is intended for tooling that echoes the field as it was entered. The min
+// and max values should be the min and max for the field being parsed. If any value fails to parse an error is provided
+// and the slice will be nil.
+func ParseFieldValueOrdered(field string, min int, max int) ([]int, error) {
+	ordered := make([]int, 0)
+	seen := make(map[int]struct{})
+	for _, value := range strings.Split(field, ",") {
+		values, _, err := parseFieldValue(value, min, max)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse value %s: %s", value, err)
+		}
+
+		for _, v := range values {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			ordered = append(ordered, v)
+		}
+	}
+	return ordered, nil
 }
 
 // parseFieldValue parses a single value of a field and returns a slice of the values that are compassed by the field
-// definition. If the field fails to parse an error is provided and the slice will be nil.
+// definition along with the kind of value parsed. If the field fails to parse an error is provided and the slice will
+// be nil.
 // The min and max values should be the min and max for the field being provided. The parser utilizes these values for
 // validation and range generation.
-func parseFieldValue(value string, min int, max int) ([]int, error) {
+func parseFieldValue(value string, min int, max int) ([]int, FieldKind, error) {
 	// Performing the regex match on the field. The match group determines the type of field provided and thus how to
 	// parse it.
 	match := re.FindAllStringSubmatch(value, -1)
 	if match == nil {
-		return nil, fmt.Errorf("[%s] is not in a supported field value format", value)
+		return nil, 0, fmt.Errorf("[%s] is not in a supported field value format", value)
 	}
 
 	// Simplifying access to the match groups and doing some nil checking.
@@ -545,10 +2061,10 @@ func parseFieldValue(value string, min int, max int) ([]int, error) {
 		// [*]
 		values, err := generateValueSlice(min, max, 1, min, max)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[1], err)
+			return nil, 0, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[1], err)
 		}
 
-		return values, nil
+		return values, FieldKindWildcard, nil
 
 	case matchGroups[2] != "":
 		// [*/#]
@@ -564,10 +2080,10 @@ func parseFieldValue(value string, min int, max int) ([]int, error) {
 
 		values, err := generateValueSlice(min, max, interval, min, max)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[2], err)
+			return nil, 0, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[2], err)
 		}
 
-		return values, nil
+		return values, FieldKindStep, nil
 
 	case matchGroups[3] != "":
 		// [#-#]
@@ -588,10 +2104,10 @@ func parseFieldValue(value string, min int, max int) ([]int, error) {
 
 		values, err := generateValueSlice(startRange, endRange, 1, min, max)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
+			return nil, 0, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
 		}
 
-		return values, nil
+		return values, FieldKindRange, nil
 
 	case matchGroups[4] != "":
 		// [#-#/#]
@@ -622,10 +2138,10 @@ func parseFieldValue(value string, min int, max int) ([]int, error) {
 
 		values, err := generateValueSlice(startRange, endRange, interval, min, max)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
+			return nil, 0, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
 		}
 
-		return values, nil
+		return values, FieldKindStep, nil
 
 	case matchGroups[5] != "":
 		// [#/#]
@@ -646,10 +2162,10 @@ func parseFieldValue(value string, min int, max int) ([]int, error) {
 
 		values, err := generateValueSlice(startRange, max, interval, min, max)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
+			return nil, 0, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
 		}
 
-		return values, nil
+		return values, FieldKindStep, nil
 
 	case matchGroups[6] != "":
 		// [#]
@@ -660,10 +2176,10 @@ func parseFieldValue(value string, min int, max int) ([]int, error) {
 
 		values, err := generateValueSlice(singleValue, singleValue, 1, min, max)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
+			return nil, 0, fmt.Errorf("failed to build values for [%s]: %s", matchGroups[3], err)
 		}
 
-		return values, nil
+		return values, FieldKindSingle, nil
 
 	default:
 		panic("field matched without a match group found")
@@ -689,6 +2205,24 @@ func fieldNameByIndex(i int) string {
 	}
 }
 
+// fieldMapByIndex returns the value map of the field specified by the index.
+func (s *Schedule) fieldMapByIndex(i int) map[int]int {
+	switch i {
+	case 0:
+		return s.Minutes
+	case 1:
+		return s.Hours
+	case 2:
+		return s.DaysOfMonth
+	case 3:
+		return s.Months
+	case 4:
+		return s.DaysOfTheWeek
+	default:
+		return nil
+	}
+}
+
 // fieldMinMaxByIndex returns the minimum and maximum value for the field specified by the index.
 func fieldMinMaxByIndex(i int) (min int, max int, err error) {
 	switch i {
@@ -746,3 +2280,110 @@ func generateValueSlice(rangeStart int, rangeEnd int, interval int, fieldMin int
 
 	return values, nil
 }
+
+// checkSpecialTokens returns a descriptive error if the value of the field at index uses the special L, W, #, or ?
+// tokens in a way that is not allowed. Combinations that are never valid, such as W with # or L within a list of
+// _listLen_ values, are reported before tokens that are simply unsupported so the error describes the actual mistake.
+func checkSpecialTokens(value string, index int, listLen int) error {
+	upper := strings.ToUpper(value)
+	hasL := strings.Contains(upper, "L")
+	hasW := strings.Contains(upper, "W")
+	hasHash := strings.Contains(upper, "#")
+
+	switch {
+	case hasW && hasHash:
+		return fmt.Errorf("W cannot be combined with #")
+	case hasL && hasHash:
+		return fmt.Errorf("L cannot be combined with #")
+	case hasL && listLen > 1:
+		return fmt.Errorf("L cannot be combined with other values in a list")
+	case hasW && listLen > 1:
+		return fmt.Errorf("W cannot be combined with other values in a list")
+	case hasL:
+		return fmt.Errorf("L is not supported")
+	case hasW:
+		return fmt.Errorf("W is not supported")
+	case strings.Contains(upper, "?"):
+		return fmt.Errorf("? is not supported")
+	case hasHash && index != 4:
+		return fmt.Errorf("# is only allowed in the day of week field")
+	case hasHash && strings.Count(upper, "#") > 1:
+		return fmt.Errorf("only one # is allowed")
+	case hasHash && !isNumeric(upper[:strings.Index(upper, "#")]):
+		return fmt.Errorf("# must follow a single day of the week rather than a range, interval, or *")
+	}
+	return nil
+}
+
+// isNumeric returns true if the value is made up of only digits.
+func isNumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenStep returns the interval of a field value written with a /. False is returned if the value has no interval or
+// the interval is not a number.
+func tokenStep(value string) (int, bool) {
+	idx := strings.Index(value, "/")
+	if idx < 0 {
+		return 0, false
+	}
+
+	step, err := strconv.Atoi(value[idx+1:])
+	if err != nil {
+		return 0, false
+	}
+	return step, true
+}
+
+// valueStep returns the step of the sorted values if they are exactly the values an */n field would generate for the
+// field min and max provided. At least three values are required to establish a step.
+func valueStep(values []int, fieldMin int, fieldMax int) (int, bool) {
+	if len(values) < 3 || values[0] != fieldMin {
+		return 0, false
+	}
+
+	step := values[1] - values[0]
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, false
+		}
+	}
+
+	// The step must also cover the full field, otherwise values beyond the last one would have been generated.
+	if values[len(values)-1]+step <= fieldMax {
+		return 0, false
+	}
+
+	return step, true
+}
+
+// formatValueList generates a comma separated list of the sorted values. Runs of three or more consecutive values are
+// collapsed into a range.
+func formatValueList(values []int) string {
+	parts := make([]string, 0, len(values))
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+
+		if j-i >= 2 {
+			parts = append(parts, fmt.Sprintf("%d-%d", values[i], values[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				parts = append(parts, strconv.Itoa(values[k]))
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
@@ -1,7 +1,10 @@
 package cronschedule_test
 
 import (
+	"context"
+	"errors"
 	"github.com/jrmycanady/cronschedule"
+	"strings"
 	"testing"
 	"time"
 )
@@ -53,3 +56,2524 @@ func BenchmarkNextExecutions(*testing.B) {
 
 	}
 }
+
+func TestShouldExecuteLeapSecond(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 22 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// Go normalizes second 60 into the first second of the next minute.
+	leap := time.Date(2020, time.July, 23, 21, 59, 60, 0, time.Local)
+	if leap.Minute() != 0 || leap.Hour() != 22 || leap.Second() != 0 {
+		t.Fatalf("expected leap second to normalize to 22:00:00 but received %v", leap)
+	}
+
+	if !schedule.ShouldExecute(leap) {
+		t.Errorf("expected schedule to execute at normalized leap second time %v", leap)
+	}
+
+	if schedule.ShouldExecute(leap.Add(-1 * time.Second)) {
+		t.Errorf("expected schedule not to execute at %v", leap.Add(-1*time.Second))
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected string
+	}{
+		{"0,15,30,45 * * * *", "*/15 * * * *"},
+		{"*/15 * * * *", "*/15 * * * *"},
+		{"0-59/20 * * * *", "*/20 * * * *"},
+		{"5,20,35,50 * * * *", "5,20,35,50 * * * *"},
+		{"0 0,12 * * *", "0 0,12 * * *"},
+		{"0 22 * * 1-5", "0 22 * * 1-5"},
+		{"23 0-20/2 * * 3,2,4,5", "23 0,2,4,6,8,10,12,14,16,18,20 * * 2-5"},
+		{"0 0 15 * 0-6", "0 0 15 * 0-6"},
+		{"0 0 1-31 * 1", "0 0 1-31 * 1"},
+		{"*/1 * * * *", "* * * * *"},
+		{"* * * * *", "* * * * *"},
+	}
+
+	for _, test := range tests {
+		normalized, err := cronschedule.Normalize(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to normalize %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if normalized != test.Expected {
+			t.Errorf("expected %s to normalize to %s but received %s", test.Schedule, test.Expected, normalized)
+		}
+	}
+}
+
+func TestNextBusinessDayExecution(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 10 * * 1,6")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// Friday after the execution time, the schedule naturally fires next on Saturday.
+	start := time.Date(2020, time.July, 24, 12, 0, 0, 0, time.Local)
+	saturday := time.Date(2020, time.July, 25, 10, 0, 0, 0, time.Local)
+	monday := time.Date(2020, time.July, 27, 10, 0, 0, 0, time.Local)
+
+	if next := schedule.NextExecution(start); !next.Equal(saturday) {
+		t.Fatalf("expected natural next execution of %v but received %v", saturday, next)
+	}
+
+	if next := schedule.NextBusinessDayExecution(start); !next.Equal(monday) {
+		t.Errorf("expected business day execution of %v but received %v", monday, next)
+	}
+
+	weekendOnly, err := cronschedule.Parse("0 10 * * 0,6")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if next := weekendOnly.NextBusinessDayExecution(start); !next.IsZero() {
+		t.Errorf("expected zero time for weekend only schedule but received %v", next)
+	}
+
+	never, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if next := never.NextBusinessDayExecution(start); !next.IsZero() {
+		t.Errorf("expected zero time for schedule that never executes but received %v", next)
+	}
+}
+
+func TestParseFieldCountError(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Got      int
+	}{
+		{"* * * *", 4},
+		{"0 * * * * *", 6},
+	}
+
+	for _, test := range tests {
+		_, err := cronschedule.Parse(test.Schedule)
+		if err == nil {
+			t.Errorf("expected error parsing %s", test.Schedule)
+			continue
+		}
+
+		var countErr *cronschedule.FieldCountError
+		if !errors.As(err, &countErr) {
+			t.Errorf("expected FieldCountError parsing %s but received %T: %s", test.Schedule, err, err)
+			continue
+		}
+
+		if countErr.Got != test.Got || countErr.Want != 5 {
+			t.Errorf("expected Got %d Want 5 parsing %s but received Got %d Want %d", test.Got, test.Schedule, countErr.Got, countErr.Want)
+		}
+	}
+}
+
+func TestCrontab(t *testing.T) {
+	schedule := cronschedule.EmptySchedule()
+	schedule.AddMinutes([]int{0, 30})
+	schedule.AddHours([]int{9, 10, 11, 12})
+	schedule.AddDaysOfMonth([]int{1, 15})
+	schedule.AddMonths([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	schedule.Rebuild()
+
+	expected := "0,30 9-12 1,15 * * /usr/bin/backup --full"
+	if line := schedule.Crontab("/usr/bin/backup --full"); line != expected {
+		t.Errorf("expected crontab line %s but received %s", expected, line)
+	}
+
+	// The rebuilt slices should be usable for generating executions.
+	next := schedule.NextExecution(time.Date(2020, time.July, 1, 8, 0, 0, 0, time.Local))
+	if expectedNext := time.Date(2020, time.July, 1, 9, 0, 0, 0, time.Local); !next.Equal(expectedNext) {
+		t.Errorf("expected next execution of %v but received %v", expectedNext, next)
+	}
+}
+
+func BenchmarkNextExecutionsLongHorizon(b *testing.B) {
+	schedule, err := cronschedule.Parse("0 0 * * *")
+	if err != nil {
+		b.Fatalf("failed to build schedule: %s", err)
+	}
+	start := time.Date(2019, time.December, 31, 12, 0, 0, 0, time.Local)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = schedule.NextExecutions(start, 3650)
+	}
+}
+
+func TestContains(t *testing.T) {
+	schedule, err := cronschedule.Parse("30 0-20/2 1,15 8 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tests := []struct {
+		Index    int
+		Value    int
+		Expected bool
+	}{
+		{0, 30, true},
+		{0, 31, false},
+		{1, 20, true},
+		{1, 21, false},
+		{2, 15, true},
+		{2, 2, false},
+		{3, 8, true},
+		{3, 9, false},
+		{4, 1, false},
+		{5, 30, false},
+		{-1, 30, false},
+	}
+
+	for _, test := range tests {
+		if contains := schedule.Contains(test.Index, test.Value); contains != test.Expected {
+			t.Errorf("expected Contains(%d, %d) to be %t but received %t", test.Index, test.Value, test.Expected, contains)
+		}
+	}
+}
+
+func TestParseEvery(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected string
+	}{
+		{"@every 15m", "*/15 * * * *"},
+		{"@every 2h", "0 */2 * * *"},
+		{"@every 24h", "0 0 * * *"},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to parse %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if schedule.ScheduleStr != test.Schedule {
+			t.Errorf("expected ScheduleStr %s but received %s", test.Schedule, schedule.ScheduleStr)
+		}
+
+		if str := schedule.String(); str != test.Expected {
+			t.Errorf("expected %s to be parsed as %s but received %s", test.Schedule, test.Expected, str)
+		}
+	}
+
+	for _, invalid := range []string{"@every 90s", "@every 1h30m", "@every 0m", "@every soon"} {
+		if _, err := cronschedule.Parse(invalid); err == nil {
+			t.Errorf("expected error parsing %s", invalid)
+		}
+	}
+}
+
+func TestLastExecutions(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tests := []struct {
+		T        time.Time
+		Expected []time.Time
+	}{
+		{
+			// Monday before the execution time so the previous executions are from the prior week.
+			T: time.Date(2020, time.July, 27, 12, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2020, time.July, 22, 22, 0, 0, 0, time.Local),
+				time.Date(2020, time.July, 23, 22, 0, 0, 0, time.Local),
+				time.Date(2020, time.July, 24, 22, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			// Exactly at an execution time which is not before t.
+			T: time.Date(2020, time.July, 24, 22, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2020, time.July, 21, 22, 0, 0, 0, time.Local),
+				time.Date(2020, time.July, 22, 22, 0, 0, 0, time.Local),
+				time.Date(2020, time.July, 23, 22, 0, 0, 0, time.Local),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		lastTimes := schedule.LastExecutions(test.T, len(test.Expected))
+		if len(lastTimes) != len(test.Expected) {
+			t.Errorf("expected %d times but received %d", len(test.Expected), len(lastTimes))
+			continue
+		}
+
+		for i := range test.Expected {
+			if !lastTimes[i].Equal(test.Expected[i]) {
+				t.Errorf("expected %v at index %d but received %v", test.Expected[i], i, lastTimes[i])
+			}
+		}
+
+		previous := schedule.PreviousExecution(test.T)
+		if !previous.Equal(test.Expected[len(test.Expected)-1]) {
+			t.Errorf("expected previous execution %v but received %v", test.Expected[len(test.Expected)-1], previous)
+		}
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if previous := unsatisfiable.PreviousExecutions(tests[0].T, 3); len(previous) != 0 {
+		t.Errorf("expected no previous executions for a schedule that never executes but received %v", previous)
+	}
+	if last := unsatisfiable.LastExecutions(tests[0].T, 3); len(last) != 0 {
+		t.Errorf("expected no last executions for a schedule that never executes but received %v", last)
+	}
+	if previous := unsatisfiable.PreviousExecution(tests[0].T); !previous.IsZero() {
+		t.Errorf("expected zero time for a schedule that never executes but received %v", previous)
+	}
+}
+
+func TestPreviousExecutionsOtherLocation(t *testing.T) {
+	schedule, err := cronschedule.Parse("* * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// 20:30 on January 1 at UTC-12 is already January 2 in most local time zones, so executions on the local day after
+	// the day of t must not be skipped.
+	start := time.Date(2020, time.January, 1, 20, 30, 0, 0, time.FixedZone("UTC-12", -12*60*60))
+	expected := []time.Time{start.Add(-1 * time.Minute), start.Add(-2 * time.Minute)}
+
+	execTimes := schedule.PreviousExecutions(start, len(expected))
+	if len(execTimes) != len(expected) {
+		t.Fatalf("expected %d times but received %d", len(expected), len(execTimes))
+	}
+	for i := range expected {
+		if !execTimes[i].Equal(expected[i]) {
+			t.Errorf("expected %v at index %d but received %v", expected[i], i, execTimes[i])
+		}
+	}
+}
+
+func TestPreviousExecutionYearBoundary(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Start    time.Time
+		Expected time.Time
+	}{
+		// Mid January returns January 1 of the current year.
+		{"0 0 1 1 *", time.Date(2021, time.January, 15, 12, 0, 0, 0, time.Local), time.Date(2021, time.January, 1, 0, 0, 0, 0, time.Local)},
+		{"0 0 1 1 *", time.Date(2021, time.January, 1, 0, 0, 30, 0, time.Local), time.Date(2021, time.January, 1, 0, 0, 0, 0, time.Local)},
+		// Exactly at the execution returns January 1 of the previous year.
+		{"0 0 1 1 *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.Local), time.Date(2020, time.January, 1, 0, 0, 0, 0, time.Local)},
+		{"0 12 1 1 *", time.Date(2021, time.January, 1, 6, 0, 0, 0, time.Local), time.Date(2020, time.January, 1, 12, 0, 0, 0, time.Local)},
+		// The walk rolls back into December of the previous year.
+		{"0 0 31 12 *", time.Date(2021, time.January, 5, 0, 0, 0, 0, time.Local), time.Date(2020, time.December, 31, 0, 0, 0, 0, time.Local)},
+		{"*/30 * * * *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.Local), time.Date(2020, time.December, 31, 23, 30, 0, 0, time.Local)},
+		{"0 9 * * 1-5", time.Date(2021, time.January, 1, 8, 0, 0, 0, time.Local), time.Date(2020, time.December, 31, 9, 0, 0, 0, time.Local)},
+		// Several years are skipped for February 29.
+		{"0 0 29 2 *", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.Local), time.Date(2020, time.February, 29, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if prev := schedule.PreviousExecution(test.Start); !prev.Equal(test.Expected) {
+			t.Errorf("expected previous execution of %s before %v to be %v but received %v", test.Schedule, test.Start, test.Expected, prev)
+		}
+	}
+}
+
+func TestFrequency(t *testing.T) {
+	expected := map[int]string{
+		0: "irregular",
+		1: "irregular",
+		2: "monthly",
+		3: "irregular",
+		4: "irregular",
+		5: "irregular",
+		6: "irregular",
+		7: "irregular",
+	}
+
+	for _, param := range CronTestData {
+		schedule, err := cronschedule.Parse(param.Schedule)
+		if err != nil {
+			t.Errorf("%d|failed to build schedule for %s: %s", param.ID, param.Schedule, err)
+			continue
+		}
+
+		if frequency := schedule.Frequency(); frequency != expected[param.ID] {
+			t.Errorf("%d|expected frequency %s for %s but received %s", param.ID, expected[param.ID], param.Schedule, frequency)
+		}
+	}
+
+	tests := []struct {
+		Schedule string
+		Expected string
+	}{
+		{"* * * * *", "minutely"},
+		{"0 * * * *", "hourly"},
+		{"30 2 * * *", "daily"},
+		{"0 0 * * 0", "weekly"},
+		{"0 0 1 * *", "monthly"},
+		{"0 0 1 1 *", "yearly"},
+		{"*/5 * * * *", "irregular"},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if frequency := schedule.Frequency(); frequency != test.Expected {
+			t.Errorf("expected frequency %s for %s but received %s", test.Expected, test.Schedule, frequency)
+		}
+	}
+}
+
+func TestParseCronTZ(t *testing.T) {
+	schedule, err := cronschedule.Parse("CRON_TZ=America/New_York 0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to parse schedule with CRON_TZ: %s", err)
+	}
+
+	if schedule.Location == nil || schedule.Location.String() != "America/New_York" {
+		t.Fatalf("expected location America/New_York but received %v", schedule.Location)
+	}
+
+	if str := schedule.String(); str != "CRON_TZ=America/New_York 0 22 * * 1-5" {
+		t.Errorf("expected String to include CRON_TZ but received %s", str)
+	}
+
+	// 22:00 in New York during daylight saving time is 02:00 UTC the following day.
+	start := time.Date(2020, time.July, 23, 12, 0, 0, 0, time.UTC)
+	expected := time.Date(2020, time.July, 24, 2, 0, 0, 0, time.UTC)
+	next := schedule.NextExecution(start)
+	if !next.Equal(expected) {
+		t.Errorf("expected next execution of %v but received %v", expected, next.UTC())
+	}
+	if next.Location() != schedule.Location {
+		t.Errorf("expected next execution in location %v but received %v", schedule.Location, next.Location())
+	}
+
+	if !schedule.ShouldExecute(expected) {
+		t.Errorf("expected schedule to execute at %v", expected)
+	}
+
+	for _, invalid := range []string{"CRON_TZ=Mars/Olympus_Mons 0 22 * * 1-5", "CRON_TZ= 0 22 * * 1-5"} {
+		if _, err := cronschedule.Parse(invalid); err == nil {
+			t.Errorf("expected error parsing %s", invalid)
+		}
+	}
+}
+
+func TestShouldExecuteNowIn(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load location: %s", err)
+	}
+
+	schedule, err := cronschedule.Parse("0 22 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// 02:00 UTC is 22:00 the prior day in New York during daylight saving time.
+	restore := cronschedule.SetNow(func() time.Time {
+		return time.Date(2020, time.July, 24, 2, 0, 0, 0, time.UTC)
+	})
+	defer restore()
+
+	if !schedule.ShouldExecuteNowIn(newYork) {
+		t.Errorf("expected schedule to execute now in %v", newYork)
+	}
+
+	if schedule.ShouldExecuteNowIn(time.UTC) {
+		t.Errorf("expected schedule not to execute now in %v", time.UTC)
+	}
+}
+
+func TestNextExecutionDayRollover(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		T        time.Time
+		Expected time.Time
+	}{
+		{
+			Schedule: "59 23 * * *",
+			T:        time.Date(2020, time.July, 23, 23, 59, 30, 0, time.Local),
+			Expected: time.Date(2020, time.July, 24, 23, 59, 0, 0, time.Local),
+		},
+		{
+			Schedule: "59 23 * * *",
+			T:        time.Date(2020, time.July, 23, 23, 58, 30, 0, time.Local),
+			Expected: time.Date(2020, time.July, 23, 23, 59, 0, 0, time.Local),
+		},
+		{
+			Schedule: "59 23 * * *",
+			T:        time.Date(2020, time.December, 31, 23, 59, 30, 0, time.Local),
+			Expected: time.Date(2021, time.January, 1, 23, 59, 0, 0, time.Local),
+		},
+		{
+			Schedule: "30 23 * * *",
+			T:        time.Date(2020, time.July, 31, 23, 45, 0, 0, time.Local),
+			Expected: time.Date(2020, time.August, 1, 23, 30, 0, 0, time.Local),
+		},
+		{
+			Schedule: "0,30 * * * *",
+			T:        time.Date(2020, time.July, 23, 10, 40, 0, 0, time.Local),
+			Expected: time.Date(2020, time.July, 23, 11, 0, 0, 0, time.Local),
+		},
+		{
+			Schedule: "0,30 23 * * *",
+			T:        time.Date(2020, time.July, 23, 23, 40, 0, 0, time.Local),
+			Expected: time.Date(2020, time.July, 24, 23, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if next := schedule.NextExecution(test.T); !next.Equal(test.Expected) {
+			t.Errorf("%s|expected next execution after %v of %v but received %v", test.Schedule, test.T, test.Expected, next)
+		}
+	}
+}
+
+func TestExplainMatch(t *testing.T) {
+	schedule, err := cronschedule.Parse("28 15 * 8 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tests := []struct {
+		T           time.Time
+		Match       bool
+		Explanation string
+	}{
+		{
+			T:           time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local),
+			Match:       false,
+			Explanation: "minute 28 ✓, hour 15 ✓, day of month 23 ✓, month July ✗ (allowed: August)",
+		},
+		{
+			T:           time.Date(2020, time.August, 23, 15, 28, 0, 0, time.Local),
+			Match:       true,
+			Explanation: "minute 28 ✓, hour 15 ✓, day of month 23 ✓, month August ✓",
+		},
+	}
+
+	for _, test := range tests {
+		match, explanation := schedule.ExplainMatch(test.T)
+		if match != test.Match {
+			t.Errorf("expected match %t at %v but received %t", test.Match, test.T, match)
+		}
+
+		if explanation != test.Explanation {
+			t.Errorf("expected explanation [%s] at %v but received [%s]", test.Explanation, test.T, explanation)
+		}
+	}
+
+	weekdays, err := cronschedule.Parse("0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	expected := "minute 0 ✓, hour 22 ✓, month July ✓, day of week Saturday ✗ (allowed: Monday,Tuesday,Wednesday,Thursday,Friday)"
+	if _, explanation := weekdays.ExplainMatch(time.Date(2020, time.July, 25, 22, 0, 0, 0, time.Local)); explanation != expected {
+		t.Errorf("expected explanation [%s] but received [%s]", expected, explanation)
+	}
+}
+
+func TestFilterMatching(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/15 9-17 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	times := []time.Time{
+		time.Date(2020, time.July, 23, 9, 15, 42, 0, time.Local),
+		time.Date(2020, time.July, 23, 9, 16, 0, 0, time.Local),
+		time.Date(2020, time.July, 23, 18, 0, 0, 0, time.Local),
+		time.Date(2020, time.July, 25, 10, 0, 0, 0, time.Local),
+		time.Date(2020, time.July, 24, 17, 45, 0, 0, time.Local),
+	}
+	expected := []time.Time{times[0], times[4]}
+
+	matching := schedule.FilterMatching(times)
+	if len(matching) != len(expected) {
+		t.Fatalf("expected %d matching times but received %d: %v", len(expected), len(matching), matching)
+	}
+
+	for i := range expected {
+		if !matching[i].Equal(expected[i]) {
+			t.Errorf("expected %v at index %d but received %v", expected[i], i, matching[i])
+		}
+	}
+}
+
+func TestFiresOnDay(t *testing.T) {
+	schedule, err := cronschedule.Parse("0,30 9-17 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	thursday := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+	if fires := schedule.FiresOnDay(thursday); fires != 18 {
+		t.Errorf("expected 18 fires on %v but received %d", thursday, fires)
+	}
+
+	saturday := time.Date(2020, time.July, 25, 15, 28, 0, 0, time.Local)
+	if fires := schedule.FiresOnDay(saturday); fires != 0 {
+		t.Errorf("expected 0 fires on %v but received %d", saturday, fires)
+	}
+}
+
+func TestParseSpacedList(t *testing.T) {
+	for _, spaced := range []string{"1, 2, 3 * * * *", "1 ,2 * * * *", "0 9 * * 1, 5"} {
+		_, err := cronschedule.Parse(spaced)
+
+		var countErr *cronschedule.FieldCountError
+		if !errors.As(err, &countErr) {
+			t.Errorf("expected FieldCountError parsing %s but received %v", spaced, err)
+			continue
+		}
+
+		if countErr.Hint != "list values must not contain spaces" {
+			t.Errorf("expected spaced list hint parsing %s but received [%s]", spaced, countErr.Hint)
+		}
+	}
+
+	_, err := cronschedule.Parse("* * * *")
+	var countErr *cronschedule.FieldCountError
+	if !errors.As(err, &countErr) || countErr.Hint != "" {
+		t.Errorf("expected FieldCountError without hint but received %v", err)
+	}
+}
+
+func TestTimesInNextDuration(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/10 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	from := time.Date(2020, time.July, 23, 10, 0, 0, 0, time.Local)
+	times := schedule.TimesInNextDuration(from, time.Hour)
+	if len(times) != 6 {
+		t.Fatalf("expected 6 times but received %d: %v", len(times), times)
+	}
+
+	for i, execTime := range times {
+		expected := from.Add(time.Duration(i+1) * 10 * time.Minute)
+		if !execTime.Equal(expected) {
+			t.Errorf("expected %v at index %d but received %v", expected, i, execTime)
+		}
+	}
+
+	// A schedule that never executes has no times within the duration.
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if times := unsatisfiable.TimesInNextDuration(from, 24*time.Hour); len(times) != 0 {
+		t.Errorf("expected no times for a schedule that never executes but received %v", times)
+	}
+}
+
+func TestReturnedSlicesAreCopies(t *testing.T) {
+	schedule, err := cronschedule.Parse("0,30 9-17 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	start := time.Date(2020, time.July, 23, 8, 0, 0, 0, time.Local)
+	expected := schedule.NextExecutions(start, 5)
+
+	for i := 0; i < 5; i++ {
+		values := schedule.FieldValues(i)
+		for j := range values {
+			values[j] = -1
+		}
+	}
+
+	execTimes := schedule.NextExecutions(start, 5)
+	execTimes[0] = time.Time{}
+
+	if str := schedule.String(); str != "0,30 9-17 * * 1-5" {
+		t.Errorf("expected schedule to be unchanged but received %s", str)
+	}
+
+	if minutes := schedule.FieldValues(0); len(minutes) != 2 || minutes[0] != 0 || minutes[1] != 30 {
+		t.Errorf("expected minute values [0 30] but received %v", minutes)
+	}
+
+	execTimes = schedule.NextExecutions(start, 5)
+	for i := range expected {
+		if !execTimes[i].Equal(expected[i]) {
+			t.Errorf("expected %v at index %d but received %v", expected[i], i, execTimes[i])
+		}
+	}
+
+	if values := schedule.FieldValues(5); values != nil {
+		t.Errorf("expected nil for invalid index but received %v", values)
+	}
+}
+
+func TestParseListWithSteppedRange(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected []int
+	}{
+		{"1,5-20/5 * * * *", []int{1, 5, 10, 15, 20}},
+		{"5-20/5,1 * * * *", []int{1, 5, 10, 15, 20}},
+		{"1,5,5-20/5,20 * * * *", []int{1, 5, 10, 15, 20}},
+		{"1,5-20/5,50/5 * * * *", []int{1, 5, 10, 15, 20, 50, 55}},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		minutes := schedule.FieldValues(0)
+		if len(minutes) != len(test.Expected) {
+			t.Errorf("expected minutes %v for %s but received %v", test.Expected, test.Schedule, minutes)
+			continue
+		}
+
+		for i := range test.Expected {
+			if minutes[i] != test.Expected[i] {
+				t.Errorf("expected minutes %v for %s but received %v", test.Expected, test.Schedule, minutes)
+				break
+			}
+		}
+	}
+}
+
+func TestParseLeadingZeros(t *testing.T) {
+	tests := []struct {
+		Padded   string
+		Unpadded string
+	}{
+		{"0-09/02 * * * *", "0-9/2 * * * *"},
+		{"*/05 * * * *", "*/5 * * * *"},
+		{"05/15 * * * *", "5/15 * * * *"},
+		{"00 09 * * *", "0 9 * * *"},
+		{"0 9-017 * * *", "0 9-17 * * *"},
+		{"0 0 001,015 * *", "0 0 1,15 * *"},
+		{"0 0 * 06-08 *", "0 0 * 6-8 *"},
+		{"0 0 * * 01-05", "0 0 * * 1-5"},
+		{"0 0 * * 07", "0 0 * * 7"},
+	}
+
+	for _, test := range tests {
+		padded, err := cronschedule.Parse(test.Padded)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Padded, err)
+			continue
+		}
+
+		unpadded, err := cronschedule.Parse(test.Unpadded)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Unpadded, err)
+			continue
+		}
+
+		if !padded.Equal(&unpadded) {
+			t.Errorf("expected %s to parse the same as %s but received %s", test.Padded, test.Unpadded, padded.String())
+		}
+	}
+
+	// Every value requires at least one digit.
+	for _, invalid := range []string{"*/ * * * *", "1- * * * *", "-5 * * * *", "1-5/ * * * *", "/5 * * * *", "1,,2 * * * *", "1, * * * *"} {
+		if _, err := cronschedule.Parse(invalid); err == nil {
+			t.Errorf("expected error parsing %s", invalid)
+		}
+	}
+}
+
+// robfigSchedule has the same shape as the Schedule interface of github.com/robfig/cron.
+type robfigSchedule interface {
+	Next(time.Time) time.Time
+}
+
+func TestNextSatisfiesRobfigSchedule(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/15 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	var robfig robfigSchedule = &schedule
+
+	start := time.Date(2020, time.July, 23, 10, 7, 30, 0, time.Local)
+	expected := schedule.NextExecution(start)
+	if next := robfig.Next(start); !next.Equal(expected) {
+		t.Errorf("expected next execution %v but received %v", expected, next)
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	robfig = &unsatisfiable
+	if next := robfig.Next(start); !next.IsZero() {
+		t.Errorf("expected zero time for an unsatisfiable schedule but received %v", next)
+	}
+}
+
+func TestPrev(t *testing.T) {
+	for _, expr := range []string{"*/15 * * * *", "0 9 * * 1-5", "30 2 29 2 *", "0 0 1 1 *"} {
+		schedule, err := cronschedule.Parse(expr)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", expr, err)
+			continue
+		}
+
+		for _, start := range []time.Time{
+			time.Date(2020, time.July, 23, 10, 7, 30, 0, time.Local),
+			time.Date(2020, time.July, 27, 9, 0, 0, 0, time.Local),
+			time.Date(2021, time.January, 1, 0, 0, 0, 0, time.Local),
+		} {
+			next := schedule.Next(start)
+			prev := schedule.Prev(next)
+			if prev.After(start) {
+				t.Errorf("%s|expected Prev(Next(%v)) to be at or before it but received %v", expr, start, prev)
+			}
+			if !prev.Before(next) {
+				t.Errorf("%s|expected Prev(%v) to be strictly before it but received %v", expr, next, prev)
+			}
+			if !schedule.Prev(start).Equal(schedule.PreviousExecution(start)) {
+				t.Errorf("%s|expected Prev to match PreviousExecution for %v", expr, start)
+			}
+		}
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if prev := unsatisfiable.Prev(time.Now()); !prev.IsZero() {
+		t.Errorf("expected zero time for an unsatisfiable schedule but received %v", prev)
+	}
+}
+
+func TestNextExecutionsCtx(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 9 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	from := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+	execTimes, err := schedule.NextExecutionsCtx(context.Background(), from, 10)
+	if err != nil {
+		t.Fatalf("expected no error but received %s", err)
+	}
+	expected := schedule.NextExecutions(from, 10)
+	for i := range expected {
+		if !execTimes[i].Equal(expected[i]) {
+			t.Errorf("expected %v at index %d but received %v", expected[i], i, execTimes[i])
+		}
+	}
+
+	// A count of 0 or less returns no times.
+	everyMinute, err := cronschedule.Parse("* * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	for _, count := range []int{0, -1} {
+		execTimes, err := everyMinute.NextExecutionsCtx(context.Background(), from, count)
+		if err != nil || len(execTimes) != 0 {
+			t.Errorf("expected no times and no error for a count of %d but received %d times and %v", count, len(execTimes), err)
+		}
+		if execTimes := everyMinute.NextExecutions(from, count); len(execTimes) != 0 {
+			t.Errorf("expected no times from NextExecutions for a count of %d but received %d", count, len(execTimes))
+		}
+	}
+
+	// February 31 never occurs so the search only ends once cancelled.
+	unsatisfiable, err := cronschedule.Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan error)
+	go func() {
+		_, err := unsatisfiable.NextExecutionsCtx(ctx, from, 1)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("expected %s but received %v", context.Canceled, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the search to stop once cancelled")
+	}
+}
+
+func TestNextExecutionsNoDuplicates(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Start    time.Time
+		Count    int
+	}{
+		// July 15 2020 is a Wednesday so it qualifies under both day fields.
+		{"CRON_TZ=UTC 0 0 15 * 3", time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC), 10},
+		{"CRON_TZ=UTC 0,30 0 15 * 3", time.Date(2020, time.July, 14, 0, 0, 0, 0, time.UTC), 10},
+		// 2:00 through 2:59 is skipped on March 8 2020 in New York normalizing to 3:00 through 3:59.
+		{"CRON_TZ=America/New_York */30 * * * *", time.Date(2020, time.March, 8, 0, 0, 0, 0, time.UTC), 20},
+		{"CRON_TZ=America/New_York 0,30 2,3 * * *", time.Date(2020, time.March, 7, 12, 0, 0, 0, time.UTC), 10},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		execTimes := schedule.NextExecutions(test.Start, test.Count)
+		if len(execTimes) != test.Count {
+			t.Errorf("%s|expected %d executions but received %d", test.Schedule, test.Count, len(execTimes))
+		}
+
+		for i := 1; i < len(execTimes); i++ {
+			if !execTimes[i].After(execTimes[i-1]) {
+				t.Errorf("%s|expected strictly increasing executions but received %v followed by %v", test.Schedule, execTimes[i-1], execTimes[i])
+			}
+		}
+	}
+
+	// Starting just before the skipped hour never returns a time before the start.
+	schedule, err := cronschedule.Parse("CRON_TZ=America/New_York */30 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	start := time.Date(2020, time.March, 8, 6, 45, 0, 0, time.UTC)
+	expected := time.Date(2020, time.March, 8, 7, 0, 0, 0, time.UTC)
+	if next := schedule.NextExecution(start); !next.Equal(expected) {
+		t.Errorf("expected next execution %v after %v but received %v", expected, start, next)
+	}
+
+	schedule, err = cronschedule.Parse("CRON_TZ=UTC 0 0 15 * 3")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	fifteenth := time.Date(2020, time.July, 15, 0, 0, 0, 0, time.UTC)
+	if fires := schedule.ExecutionsBetween(fifteenth.Add(-time.Hour), fifteenth.Add(time.Hour)); len(fires) != 1 {
+		t.Errorf("expected a single fire on July 15 2020 but received %v", fires)
+	}
+}
+
+func TestNextExecutionIgnoringSeconds(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/5 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// The current minute matches the schedule but is partway through.
+	start := time.Date(2020, time.July, 23, 10, 5, 30, 0, time.Local)
+	expected := time.Date(2020, time.July, 23, 10, 10, 0, 0, time.Local)
+	if next := schedule.NextExecutionIgnoringSeconds(start); !next.Equal(expected) {
+		t.Errorf("expected next execution of %v but received %v", expected, next)
+	}
+
+	start = time.Date(2020, time.July, 23, 10, 4, 59, 0, time.Local)
+	expected = time.Date(2020, time.July, 23, 10, 5, 0, 0, time.Local)
+	if next := schedule.NextExecutionIgnoringSeconds(start); !next.Equal(expected) {
+		t.Errorf("expected next execution of %v but received %v", expected, next)
+	}
+}
+
+func TestFireInstant(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/5 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	expected := time.Date(2020, time.July, 23, 10, 5, 0, 0, time.Local)
+	for _, minute := range []time.Time{
+		time.Date(2020, time.July, 23, 10, 5, 0, 0, time.Local),
+		time.Date(2020, time.July, 23, 10, 5, 42, 0, time.Local),
+		time.Date(2020, time.July, 23, 10, 5, 59, 999999999, time.Local),
+	} {
+		instant := schedule.FireInstant(minute)
+		if !instant.Equal(expected) || instant.Second() != 0 || instant.Nanosecond() != 0 {
+			t.Errorf("expected fire instant %v for %v but received %v", expected, minute, instant)
+		}
+	}
+
+	// The fire instant is the same instant NextExecution generates.
+	start := time.Date(2020, time.July, 23, 10, 4, 10, 0, time.Local)
+	if next := schedule.NextExecution(start); next != schedule.FireInstant(next.Add(30*time.Second)) {
+		t.Errorf("expected fire instant to match next execution %v", next)
+	}
+
+	// A monotonic clock reading is stripped.
+	if instant := schedule.FireInstant(time.Now()); instant != instant.Round(0) {
+		t.Errorf("expected fire instant without a monotonic clock reading")
+	}
+}
+
+func TestNextAligned(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/5 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tests := []struct {
+		Start    time.Time
+		Expected time.Time
+	}{
+		{time.Date(2020, time.July, 23, 10, 5, 30, 0, time.Local), time.Date(2020, time.July, 23, 10, 5, 0, 0, time.Local)},
+		{time.Date(2020, time.July, 23, 10, 5, 0, 0, time.Local), time.Date(2020, time.July, 23, 10, 5, 0, 0, time.Local)},
+		{time.Date(2020, time.July, 23, 10, 5, 59, 999, time.Local), time.Date(2020, time.July, 23, 10, 5, 0, 0, time.Local)},
+		{time.Date(2020, time.July, 23, 10, 6, 1, 0, time.Local), time.Date(2020, time.July, 23, 10, 10, 0, 0, time.Local)},
+	}
+
+	for _, test := range tests {
+		if next := schedule.NextAligned(test.Start); !next.Equal(test.Expected) {
+			t.Errorf("expected aligned execution of %v for %v but received %v", test.Expected, test.Start, next)
+		}
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if next := unsatisfiable.NextAligned(tests[0].Start); !next.IsZero() {
+		t.Errorf("expected zero time for a schedule that never executes but received %v", next)
+	}
+}
+
+func TestIsDue(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	at := func(hour int, minute int, second int) time.Time {
+		return time.Date(2020, time.July, 23, hour, minute, second, 0, time.Local)
+	}
+
+	tests := []struct {
+		LastRun   time.Time
+		Now       time.Time
+		Tolerance time.Duration
+		Expected  bool
+	}{
+		// The 11:00 execution has not been run.
+		{at(10, 0, 5), at(11, 0, 0), 0, true},
+		{at(10, 0, 5), at(11, 30, 0), 0, true},
+		// The 11:00 execution has not been reached.
+		{at(10, 0, 5), at(10, 59, 58), 0, false},
+		// Waking up just before 11:00 is within the tolerance.
+		{at(10, 0, 5), at(10, 59, 58), 5 * time.Second, true},
+		// The last run started just before 11:00 which is within the tolerance so it covered the execution.
+		{at(10, 59, 58), at(11, 30, 0), 5 * time.Second, false},
+		{at(10, 59, 58), at(11, 30, 0), 0, true},
+		// The 11:00 execution was run.
+		{at(11, 0, 1), at(11, 59, 0), 0, false},
+	}
+
+	for _, test := range tests {
+		if due := schedule.IsDue(test.LastRun, test.Now, test.Tolerance); due != test.Expected {
+			t.Errorf("expected due of %t for last run %v, now %v, and tolerance %v but received %t", test.Expected, test.LastRun, test.Now, test.Tolerance, due)
+		}
+	}
+}
+
+func TestNextExecutionSkip(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 9 * * 1")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	start := time.Date(2020, time.July, 1, 0, 0, 0, 0, time.Local)
+	fires := schedule.NextExecutions(start, 7)
+
+	for _, skipEvery := range []int{2, 3} {
+		next := fires[0]
+		for i := skipEvery; i < len(fires); i += skipEvery {
+			next = schedule.NextExecutionSkip(next, skipEvery)
+			if !next.Equal(fires[i]) {
+				t.Errorf("expected fire %d of %v skipping every %d but received %v", i, fires[i], skipEvery, next)
+			}
+		}
+	}
+
+	if next := schedule.NextExecutionSkip(fires[0], 1); !next.Equal(fires[1]) {
+		t.Errorf("expected next fire %v skipping every 1 but received %v", fires[1], next)
+	}
+
+	for _, skipEvery := range []int{-1, 0} {
+		if next := schedule.NextExecutionSkip(fires[0], skipEvery); !next.IsZero() {
+			t.Errorf("expected zero time skipping every %d but received %v", skipEvery, next)
+		}
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if next := unsatisfiable.NextExecutionSkip(start, 2); !next.IsZero() {
+		t.Errorf("expected zero time for a schedule that never executes but received %v", next)
+	}
+}
+
+func TestNextExecutionAvoiding(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Busy     map[int]bool
+		Expected time.Time
+	}{
+		// The natural next minute is busy.
+		{"0,15,30,45 * * * *", map[int]bool{30: true}, time.Date(2020, time.July, 23, 10, 45, 0, 0, time.Local)},
+		{"0,15,30,45 * * * *", map[int]bool{30: true, 45: true}, time.Date(2020, time.July, 23, 11, 0, 0, 0, time.Local)},
+		{"0,15,30,45 * * * *", map[int]bool{30: false}, time.Date(2020, time.July, 23, 10, 30, 0, 0, time.Local)},
+		// The remaining minutes of the hour are busy so the next hour is used.
+		{"30,45 10,12 * * *", map[int]bool{30: true}, time.Date(2020, time.July, 23, 10, 45, 0, 0, time.Local)},
+		{"0,30 10 * * *", map[int]bool{30: true}, time.Date(2020, time.July, 24, 10, 0, 0, 0, time.Local)},
+		// Every minute is busy.
+		{"30 * * * *", map[int]bool{30: true}, time.Time{}},
+	}
+
+	start := time.Date(2020, time.July, 23, 10, 20, 0, 0, time.Local)
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if next := schedule.NextExecutionAvoiding(start, test.Busy); !next.Equal(test.Expected) {
+			t.Errorf("expected next execution of %s avoiding %v to be %v but received %v", test.Schedule, test.Busy, test.Expected, next)
+		}
+	}
+}
+
+func TestNextExecutionOutside(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	start := time.Date(2020, time.July, 23, 10, 30, 0, 0, time.Local)
+	tests := []struct {
+		BlackoutStart time.Time
+		BlackoutEnd   time.Time
+		Expected      time.Time
+	}{
+		// The blackout covers the natural next execution.
+		{time.Date(2020, time.July, 23, 10, 45, 0, 0, time.Local), time.Date(2020, time.July, 23, 12, 15, 0, 0, time.Local), time.Date(2020, time.July, 23, 13, 0, 0, 0, time.Local)},
+		// The blackout boundaries are inclusive.
+		{time.Date(2020, time.July, 23, 11, 0, 0, 0, time.Local), time.Date(2020, time.July, 23, 12, 0, 0, 0, time.Local), time.Date(2020, time.July, 23, 13, 0, 0, 0, time.Local)},
+		// The blackout starts after the natural next execution.
+		{time.Date(2020, time.July, 23, 11, 15, 0, 0, time.Local), time.Date(2020, time.July, 23, 14, 0, 0, 0, time.Local), time.Date(2020, time.July, 23, 11, 0, 0, 0, time.Local)},
+		// The blackout ended before the natural next execution.
+		{time.Date(2020, time.July, 23, 8, 0, 0, 0, time.Local), time.Date(2020, time.July, 23, 10, 45, 0, 0, time.Local), time.Date(2020, time.July, 23, 11, 0, 0, 0, time.Local)},
+	}
+
+	for _, test := range tests {
+		if next := schedule.NextExecutionOutside(start, test.BlackoutStart, test.BlackoutEnd); !next.Equal(test.Expected) {
+			t.Errorf("expected next execution outside %v through %v of %v but received %v", test.BlackoutStart, test.BlackoutEnd, test.Expected, next)
+		}
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if next := unsatisfiable.NextExecutionOutside(start, tests[0].BlackoutStart, tests[0].BlackoutEnd); !next.IsZero() {
+		t.Errorf("expected zero time for a schedule that never executes but received %v", next)
+	}
+}
+
+func TestIsSubsetOf(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Other    string
+		Expected bool
+	}{
+		{"0 10 * * 1", "0 10 * * 1-5", true},
+		{"0 10 * * 1-5", "0 10 * * 1", false},
+		{"0 10 * * 6", "0 10 * * 1-5", false},
+		{"0 10 * * 1", "0 10 * * *", true},
+		{"0 10 15 * *", "0 10 * * *", true},
+		{"0,30 10 * * 1", "0 10 * * 1-5", false},
+		{"0 10 * 8 1", "0 10 * 6-8 1-5", true},
+		{"0 10 1 * *", "0 10 * * 1-5", false},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		other, err := cronschedule.Parse(test.Other)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Other, err)
+			continue
+		}
+
+		if subset := schedule.IsSubsetOf(&other); subset != test.Expected {
+			t.Errorf("expected %s subset of %s to be %t but received %t", test.Schedule, test.Other, test.Expected, subset)
+		}
+	}
+}
+
+func TestTypedFieldValues(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 0 * 8 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	expectedWeekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	weekdays := schedule.Weekdays()
+	if len(weekdays) != len(expectedWeekdays) {
+		t.Fatalf("expected weekdays %v but received %v", expectedWeekdays, weekdays)
+	}
+	for i := range expectedWeekdays {
+		if weekdays[i] != expectedWeekdays[i] {
+			t.Errorf("expected weekdays %v but received %v", expectedWeekdays, weekdays)
+			break
+		}
+	}
+
+	months := schedule.MonthsTyped()
+	if len(months) != 1 || months[0] != time.August {
+		t.Errorf("expected months [August] but received %v", months)
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, param := range CronTestData {
+		schedule, err := cronschedule.Parse(param.Schedule)
+		if err != nil {
+			t.Errorf("%d|failed to build schedule for %s: %s", param.ID, param.Schedule, err)
+			continue
+		}
+
+		roundTrip, err := cronschedule.Parse(schedule.String())
+		if err != nil {
+			t.Errorf("%d|failed to parse String %s of %s: %s", param.ID, schedule.String(), param.Schedule, err)
+			continue
+		}
+
+		if !schedule.Equal(&roundTrip) {
+			t.Errorf("%d|expected %s to round trip through %s", param.ID, param.Schedule, schedule.String())
+		}
+	}
+
+	a, _ := cronschedule.Parse("0 22 * * 1-5")
+	b, _ := cronschedule.Parse("0 22 * * 1,2,3,4,5")
+	c, _ := cronschedule.Parse("0 22 * * 1-4")
+	if !a.Equal(&b) {
+		t.Errorf("expected %s to equal %s", a.ScheduleStr, b.ScheduleStr)
+	}
+	if a.Equal(&c) {
+		t.Errorf("expected %s not to equal %s", a.ScheduleStr, c.ScheduleStr)
+	}
+}
+
+func TestExecutionsBetween(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/15 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// Executions land exactly on both the start and the end.
+	start := time.Date(2020, time.July, 23, 10, 0, 0, 0, time.Local)
+	end := time.Date(2020, time.July, 23, 11, 0, 0, 0, time.Local)
+
+	halfOpen := schedule.ExecutionsBetween(start, end)
+	if len(halfOpen) != 4 || !halfOpen[0].Equal(start) || !halfOpen[3].Equal(end.Add(-15*time.Minute)) {
+		t.Errorf("expected 4 executions from %v up to %v but received %v", start, end, halfOpen)
+	}
+
+	closed := schedule.ExecutionsBetweenInclusive(start, end)
+	if len(closed) != 5 || !closed[0].Equal(start) || !closed[4].Equal(end) {
+		t.Errorf("expected 5 executions from %v through %v but received %v", start, end, closed)
+	}
+
+	// Neither boundary is an execution.
+	start = time.Date(2020, time.July, 23, 10, 0, 30, 0, time.Local)
+	end = time.Date(2020, time.July, 23, 10, 59, 0, 0, time.Local)
+	if execTimes := schedule.ExecutionsBetween(start, end); len(execTimes) != 3 {
+		t.Errorf("expected 3 executions from %v up to %v but received %v", start, end, execTimes)
+	}
+	if execTimes := schedule.ExecutionsBetweenInclusive(start, end); len(execTimes) != 3 {
+		t.Errorf("expected 3 executions from %v through %v but received %v", start, end, execTimes)
+	}
+
+	if execTimes := schedule.ExecutionsBetween(end, start); len(execTimes) != 0 {
+		t.Errorf("expected no executions for a reversed window but received %v", execTimes)
+	}
+}
+
+func TestWeekdayDistribution(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 9,17 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// Two full weeks starting on Sunday, July 19 2020.
+	start := time.Date(2020, time.July, 19, 0, 0, 0, 0, time.Local)
+	counts := schedule.WeekdayDistribution(start, start.AddDate(0, 0, 14))
+
+	expected := [7]int{0, 4, 4, 4, 4, 4, 0}
+	if counts != expected {
+		t.Errorf("expected weekday distribution %v but received %v", expected, counts)
+	}
+
+	if counts[time.Saturday] != 0 || counts[time.Sunday] != 0 {
+		t.Errorf("expected no weekend executions but received %d on Saturday and %d on Sunday", counts[time.Saturday], counts[time.Sunday])
+	}
+}
+
+func TestParseFieldValueOrdered(t *testing.T) {
+	tests := []struct {
+		Field    string
+		Expected []int
+	}{
+		{"3,2,4,5", []int{3, 2, 4, 5}},
+		{"5,1-6", []int{5, 1, 2, 3, 4, 6}},
+		{"3,3,2", []int{3, 2}},
+		{"40-59/10,*/20", []int{40, 50, 0, 20}},
+	}
+
+	for _, test := range tests {
+		values, err := cronschedule.ParseFieldValueOrdered(test.Field, cronschedule.FieldMinuteMin, cronschedule.FieldMinuteMax)
+		if err != nil {
+			t.Errorf("failed to parse %s: %s", test.Field, err)
+			continue
+		}
+
+		if len(values) != len(test.Expected) {
+			t.Errorf("expected %s to provide %v but received %v", test.Field, test.Expected, values)
+			continue
+		}
+		for i := range values {
+			if values[i] != test.Expected[i] {
+				t.Errorf("expected %s to provide %v but received %v", test.Field, test.Expected, values)
+				break
+			}
+		}
+	}
+
+	if values, err := cronschedule.ParseFieldValueOrdered("3,60", cronschedule.FieldMinuteMin, cronschedule.FieldMinuteMax); err == nil || values != nil {
+		t.Errorf("expected error and nil values for an out of range value but received %v, %v", values, err)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	schedule, err := cronschedule.Parse("CRON_TZ=UTC 0 9,17 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	from := time.Date(2020, time.July, 23, 8, 30, 0, 0, time.UTC)
+	timeline := schedule.Simulate(from, 3)
+
+	lines := strings.Split(strings.TrimSuffix(timeline, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines but received %d: %s", len(lines), timeline)
+	}
+
+	expected := []string{
+		"Thu 2020-07-23 09:00 UTC (+30m0s)",
+		"Thu 2020-07-23 17:00 UTC (+8h0m0s)",
+		"Fri 2020-07-24 09:00 UTC (+16h0m0s)",
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("expected line %d to be %s but received %s", i, expected[i], lines[i])
+		}
+	}
+
+	if timeline := schedule.Simulate(from, 0); timeline != "" {
+		t.Errorf("expected empty timeline for a count of 0 but received %s", timeline)
+	}
+
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if timeline := unsatisfiable.Simulate(from, 3); timeline != "" {
+		t.Errorf("expected empty timeline for a schedule that never executes but received %s", timeline)
+	}
+}
+
+func TestFireCountInMonth(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Year     int
+		Month    time.Month
+		Expected int
+	}{
+		// Every day of February differs between leap and non leap years.
+		{"0 9 * * *", 2020, time.February, 29},
+		{"0 9 * * *", 2021, time.February, 28},
+		{"0,30 9,17 * * *", 2020, time.February, 116},
+		{"0 9 29 2 *", 2020, time.February, 1},
+		{"0 9 29 2 *", 2021, time.February, 0},
+		{"0 9 31 * *", 2020, time.April, 0},
+		// July 2020 has 23 weekdays.
+		{"0 9 * * 1-5", 2020, time.July, 23},
+		{"*/15 9-16 * * 1-5", 2020, time.July, 23 * 8 * 4},
+		// The 15th of July 2020 is a Wednesday so it is only counted once.
+		{"0 9 15 * 3", 2020, time.July, 5},
+		{"0 9 * 6 1-5", 2020, time.July, 0},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if count := schedule.FireCountInMonth(test.Year, test.Month); count != test.Expected {
+			t.Errorf("expected %s to fire %d times in %s %d but received %d", test.Schedule, test.Expected, test.Month, test.Year, count)
+		}
+	}
+}
+
+func TestLongestGap(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Start    time.Time
+		End      time.Time
+		Expected time.Duration
+	}{
+		// Friday 23:00 through Monday 00:00.
+		{"CRON_TZ=UTC 0 * * * 1-5", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.August, 3, 0, 0, 0, 0, time.UTC), 49 * time.Hour},
+		// The window ends before the weekend.
+		{"CRON_TZ=UTC 0 * * * 1-5", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 25, 0, 0, 0, 0, time.UTC), time.Hour},
+		{"CRON_TZ=UTC 0,10 9 * * *", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 23, 0, 0, 0, 0, time.UTC), 23*time.Hour + 50*time.Minute},
+		// A single execution has no gap.
+		{"CRON_TZ=UTC 0 9 * * *", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 21, 0, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if gap := schedule.LongestGap(test.Start, test.End); gap != test.Expected {
+			t.Errorf("expected longest gap of %v for %s between %v and %v but received %v", test.Expected, test.Schedule, test.Start, test.End, gap)
+		}
+	}
+}
+
+func TestFieldCardinality(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected [5]int
+	}{
+		{"*/15 9-17 * * 1-5", [5]int{4, 9, 0, 12, 5}},
+		{"0 0 1,15 * *", [5]int{1, 1, 2, 12, 0}},
+		{"* * * * *", [5]int{60, 24, 31, 12, 0}},
+		{"0 9 * * 1,5#1", [5]int{1, 1, 0, 12, 2}},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if counts := schedule.FieldCardinality(); counts != test.Expected {
+			t.Errorf("expected cardinality %v for %s but received %v", test.Expected, test.Schedule, counts)
+		}
+	}
+}
+
+func TestIsEveryMinute(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected bool
+	}{
+		{"* * * * *", true},
+		{"*/1 * * * *", true},
+		{"0-59 */1 1-31 1-12 *", true},
+		{"* * * * 0-6", true},
+		{"* * * * 0-7", true},
+		{"*/2 * * * *", false},
+		{"* 0-22 * * *", false},
+		{"* * * 1-11 *", false},
+		{"* * * * 1-5", false},
+		{"* * 1-30 * *", false},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if everyMinute := schedule.IsEveryMinute(); everyMinute != test.Expected {
+			t.Errorf("expected every minute of %t for %s but received %t", test.Expected, test.Schedule, everyMinute)
+		}
+	}
+
+	schedule, err := cronschedule.Parse("*/1 0 * * 1")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if !schedule.IsWildcard(0) || schedule.IsWildcard(1) || schedule.IsWildcard(2) || !schedule.IsWildcard(3) || schedule.IsWildcard(4) || schedule.IsWildcard(5) {
+		t.Errorf("expected only the minute and month fields of */1 0 * * 1 to be wildcards")
+	}
+}
+
+func TestMinuteMask(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected uint64
+	}{
+		{"0,30 * * * *", (1 << 0) | (1 << 30)},
+		{"59 * * * *", 1 << 59},
+		{"* * * * *", (1 << 60) - 1},
+		{"*/15 * * * *", (1 << 0) | (1 << 15) | (1 << 30) | (1 << 45)},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if mask := schedule.MinuteMask(); mask != test.Expected {
+			t.Errorf("expected minute mask %b for %s but received %b", test.Expected, test.Schedule, mask)
+		}
+	}
+}
+
+func TestEqualIgnoringTZ(t *testing.T) {
+	utc, err := cronschedule.Parse("CRON_TZ=UTC 0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tokyo, err := cronschedule.Parse("CRON_TZ=Asia/Tokyo 0 22 * * 1,2,3,4,5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	local, err := cronschedule.Parse("0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if utc.Equal(&tokyo) || utc.Equal(&local) {
+		t.Errorf("expected schedules in different locations not to be equal")
+	}
+
+	if !utc.EqualIgnoringTZ(&tokyo) || !utc.EqualIgnoringTZ(&local) {
+		t.Errorf("expected schedules with the same fields to be equal ignoring the location")
+	}
+
+	other, err := cronschedule.Parse("CRON_TZ=UTC 0 22 * * 1-4")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if utc.EqualIgnoringTZ(&other) {
+		t.Errorf("expected schedules with different fields not to be equal ignoring the location")
+	}
+}
+
+func TestKey(t *testing.T) {
+	schedules := make(map[string]cronschedule.Schedule)
+	for _, expr := range []string{"0 22 * * 1-5", "0 22 * * 1,2,3,4,5", "00 22 * 1-12 01-05", "0 22 * * 1-4", "CRON_TZ=UTC 0 22 * * 1-5"} {
+		schedule, err := cronschedule.Parse(expr)
+		if err != nil {
+			t.Fatalf("failed to build schedule for %s: %s", expr, err)
+		}
+		schedules[schedule.Key()] = schedule
+	}
+
+	if len(schedules) != 3 {
+		t.Errorf("expected 3 distinct keys but received %d", len(schedules))
+	}
+
+	a, _ := cronschedule.Parse("0 22 * * 1-5")
+	b, _ := cronschedule.Parse("0 22 * * 1,2,3,4,5")
+	if a.Key() != b.Key() {
+		t.Errorf("expected equal schedules to have the same key but received %s and %s", a.Key(), b.Key())
+	}
+
+	normalized, err := cronschedule.Normalize(b.ScheduleStr)
+	if err != nil || normalized != b.Key() {
+		t.Errorf("expected key %s to match the normalized schedule %s", b.Key(), normalized)
+	}
+}
+
+func TestNextExecutionsUntil(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/10 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	from := time.Date(2020, time.July, 23, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		Until    time.Time
+		MaxCount int
+		Expected int
+	}{
+		// The count limit is reached first.
+		{from.Add(24 * time.Hour), 4, 4},
+		// The time limit is reached first, an execution exactly at until is excluded.
+		{from.Add(time.Hour), 100, 5},
+		{from.Add(time.Hour + time.Second), 100, 6},
+		{from, 100, 0},
+	}
+
+	for _, test := range tests {
+		execTimes := schedule.NextExecutionsUntil(from, test.Until, test.MaxCount)
+		if len(execTimes) != test.Expected {
+			t.Errorf("expected %d times until %v with max %d but received %d: %v", test.Expected, test.Until, test.MaxCount, len(execTimes), execTimes)
+			continue
+		}
+
+		for i, execT := range execTimes {
+			if expected := from.Add(time.Duration(i+1) * 10 * time.Minute); !execT.Equal(expected) {
+				t.Errorf("expected %v at index %d but received %v", expected, i, execT)
+			}
+		}
+	}
+
+	// A schedule that never executes stops rather than searching forever.
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if execTimes := unsatisfiable.NextExecutionsUntil(from, from.AddDate(10, 0, 0), 5); len(execTimes) != 0 {
+		t.Errorf("expected no times for a schedule that never executes but received %v", execTimes)
+	}
+}
+
+func TestSingleValues(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 22 * 8 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tests := []struct {
+		Name     string
+		Single   func() (int, bool)
+		Value    int
+		Expected bool
+	}{
+		{"minute", schedule.SingleMinute, 0, true},
+		{"hour", schedule.SingleHour, 22, true},
+		{"day of month", schedule.SingleDayOfMonth, 0, false},
+		{"month", schedule.SingleMonth, 8, true},
+		{"day of week", schedule.SingleDayOfTheWeek, 0, false},
+	}
+
+	for _, test := range tests {
+		value, ok := test.Single()
+		if ok != test.Expected || value != test.Value {
+			t.Errorf("expected single %s of (%d, %t) but received (%d, %t)", test.Name, test.Value, test.Expected, value, ok)
+		}
+	}
+}
+
+func TestAtTime(t *testing.T) {
+	sample := time.Date(2020, time.July, 23, 15, 28, 45, 0, time.Local)
+	schedule := cronschedule.AtTime(sample)
+
+	if schedule.ScheduleStr != "28 15 23 7 *" {
+		t.Errorf("expected schedule 28 15 23 7 * but received %s", schedule.ScheduleStr)
+	}
+
+	expected := time.Date(2021, time.July, 23, 15, 28, 0, 0, time.Local)
+	if next := schedule.NextExecution(sample); !next.Equal(expected) {
+		t.Errorf("expected next execution of %v but received %v", expected, next)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 9 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	fragment, err := cronschedule.Parse("30 12 * 6 0,6")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	schedule.Merge(&fragment)
+
+	if str := schedule.String(); str != "0,30 9,12 * * 0-6" {
+		t.Errorf("expected merged schedule 0,30 9,12 * * 0-6 but received %s", str)
+	}
+
+	if len(schedule.MinutesSlice) != 2 || schedule.MinutesSlice[1] != 30 {
+		t.Errorf("expected rebuilt minutes [0 30] but received %v", schedule.MinutesSlice)
+	}
+
+	if len(schedule.DaysOfWeekSlice) != 7 {
+		t.Errorf("expected rebuilt days of week [0-6] but received %v", schedule.DaysOfWeekSlice)
+	}
+
+	if len(schedule.MinutesStr) != 2 {
+		t.Errorf("expected merged minute strings but received %v", schedule.MinutesStr)
+	}
+
+	// The original fragment is left unchanged.
+	if str := fragment.String(); str != "30 12 * 6 0,6" {
+		t.Errorf("expected fragment to be unchanged but received %s", str)
+	}
+}
+
+func TestNormalizeStrings(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/15 9 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// Hand editing the maps leaves the strings describing values that are no longer resolved.
+	delete(schedule.Minutes, 45)
+	schedule.Hours[17] = 17
+	delete(schedule.DaysOfTheWeek, 5)
+
+	schedule.NormalizeStrings()
+
+	if schedule.ScheduleStr != "0,15,30 9,17 * * 1-4" {
+		t.Errorf("expected normalized schedule 0,15,30 9,17 * * 1-4 but received %s", schedule.ScheduleStr)
+	}
+
+	expected := [][]string{{"0,15,30"}, {"9,17"}, {"*"}, {"*"}, {"1-4"}}
+	received := [][]string{schedule.MinutesStr, schedule.HoursStr, schedule.DaysOfMonthStr, schedule.MonthsStr, schedule.DaysOfTheWeekStr}
+	for i := range expected {
+		if len(received[i]) != 1 || received[i][0] != expected[i][0] {
+			t.Errorf("expected field %d strings %v but received %v", i, expected[i], received[i])
+		}
+	}
+
+	if len(schedule.HoursSlice) != 2 || schedule.HoursSlice[1] != 17 {
+		t.Errorf("expected rebuilt hours [9 17] but received %v", schedule.HoursSlice)
+	}
+
+	if !strings.Contains(schedule.PrettyString(), "[0,15,30 9,17 * * 1-4]") {
+		t.Errorf("expected pretty string to contain the normalized schedule but received %s", schedule.PrettyString())
+	}
+}
+
+func TestOverlapsWithin(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Other    string
+		Start    time.Time
+		End      time.Time
+		Expected bool
+	}{
+		// Both fire at 02:00 on Sundays.
+		{"CRON_TZ=UTC 0 2 * * 0", "CRON_TZ=UTC 0 */2 * * *", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 27, 0, 0, 0, 0, time.UTC), true},
+		// The shared minute falls on the start of the window.
+		{"CRON_TZ=UTC 0 2 * * 0", "CRON_TZ=UTC 0 */2 * * *", time.Date(2020, time.July, 26, 2, 0, 0, 0, time.UTC), time.Date(2020, time.July, 26, 3, 0, 0, 0, time.UTC), true},
+		// The shared minute falls on the end of the window which is excluded.
+		{"CRON_TZ=UTC 0 2 * * 0", "CRON_TZ=UTC 0 */2 * * *", time.Date(2020, time.July, 26, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 26, 2, 0, 0, 0, time.UTC), false},
+		// The schedules never fire in the same minute.
+		{"CRON_TZ=UTC 15 * * * *", "CRON_TZ=UTC 45 * * * *", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 27, 0, 0, 0, 0, time.UTC), false},
+		// The same wall clock minute in different locations is a different instant.
+		{"CRON_TZ=UTC 0 2 * * *", "CRON_TZ=America/New_York 0 2 * * *", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 27, 0, 0, 0, 0, time.UTC), false},
+		{"CRON_TZ=UTC 0 6 * * *", "CRON_TZ=America/New_York 0 2 * * *", time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC), time.Date(2020, time.July, 27, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		other, err := cronschedule.Parse(test.Other)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Other, err)
+			continue
+		}
+
+		if overlaps := schedule.OverlapsWithin(&other, test.Start, test.End); overlaps != test.Expected {
+			t.Errorf("expected overlap of %s and %s between %v and %v to be %t but received %t", test.Schedule, test.Other, test.Start, test.End, test.Expected, overlaps)
+		}
+	}
+}
+
+func TestExpandedFields(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/30 * * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	expected := [5]string{
+		"0,30",
+		"0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23",
+		"",
+		"1,2,3,4,5,6,7,8,9,10,11,12",
+		"1,2,3,4,5",
+	}
+
+	fields := schedule.ExpandedFields()
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("expected field %d to expand to %s but received %s", i, expected[i], fields[i])
+		}
+	}
+
+	other, err := cronschedule.Parse("0,30 0-23 * 1-12 1,2,3-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if other.ExpandedFields() != fields {
+		t.Errorf("expected equivalent schedules to expand identically but received %v and %v", fields, other.ExpandedFields())
+	}
+}
+
+func TestPrettyStringClearedDays(t *testing.T) {
+	tests := []struct {
+		Schedule    string
+		DaysOfMonth string
+		DaysOfWeek  string
+	}{
+		// Both day fields are specified so neither is cleared.
+		{"0 0 15 * 1", "[15] => [[]int{15}]", "[1] => [[]int{1}]"},
+		{"0 0 15 * *", "[15] => [[]int{15}]", "[*] => [(ignored, day-of-month specified)]"},
+		{"0 0 * * 1", "[*] => [(ignored, day-of-week specified)]", "[1] => [[]int{1}]"},
+		{"0 0 * * *", "[*] => [[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}]", "[*] => [*]"},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		pretty := schedule.PrettyString()
+		if !strings.Contains(pretty, "Days Of The Month: "+test.DaysOfMonth+"\n") {
+			t.Errorf("expected day of month %s for %s but received %s", test.DaysOfMonth, test.Schedule, pretty)
+		}
+		if !strings.Contains(pretty, "Day Of The Week:   "+test.DaysOfWeek+"\n") {
+			t.Errorf("expected day of week %s for %s but received %s", test.DaysOfWeek, test.Schedule, pretty)
+		}
+	}
+
+	schedule, err := cronschedule.Parse("0 0 15 * 1")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if schedule.DaysOfMonthCleared || schedule.DaysOfTheWeekCleared {
+		t.Errorf("expected neither day field to be cleared for 0 0 15 * 1")
+	}
+}
+
+func TestPrettyStringGolden(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected string
+	}{
+		{
+			"0,15,30 9,17 * * 1-4",
+			"Cron Schedule:     [0,15,30 9,17 * * 1-4]\n" +
+				"Minute:            [0 15 30] => [[]int{0, 15, 30}]\n" +
+				"Hour:              [9 17] => [[]int{9, 17}]\n" +
+				"Days Of The Month: [*] => [(ignored, day-of-week specified)]\n" +
+				"Month:             [*] => [[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}]\n" +
+				"Day Of The Week:   [1-4] => [[]int{1, 2, 3, 4}]\n",
+		},
+		{
+			"0 9 * * 1,5#1",
+			"Cron Schedule:     [0 9 * * 1,5#1]\n" +
+				"Minute:            [0] => [[]int{0}]\n" +
+				"Hour:              [9] => [[]int{9}]\n" +
+				"Days Of The Month: [*] => [(ignored, day-of-week specified)]\n" +
+				"Month:             [*] => [[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}]\n" +
+				"Day Of The Week:   [1 5#1] => [[]int{1} [5#1]]\n",
+		},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if pretty := schedule.PrettyString(); pretty != test.Expected {
+			t.Errorf("expected pretty string %q for %s but received %q", test.Expected, test.Schedule, pretty)
+		}
+	}
+
+	// The output must be identical to the previous implementation.
+	for _, expr := range []string{"* * * * *", "0 0 15 * 1", "0 0 15 * *", "*/5 1-3 1,15 1-6 0#2", "0 0 * * *"} {
+		schedule, err := cronschedule.Parse(expr)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", expr, err)
+			continue
+		}
+
+		if pretty, concat := schedule.PrettyString(), schedule.PrettyStringConcat(); pretty != concat {
+			t.Errorf("expected pretty string %q for %s but received %q", concat, expr, pretty)
+		}
+	}
+
+	schedule := cronschedule.EmptySchedule()
+	schedule.AddMinutes([]int{30, 5})
+	schedule.AddMonths([]int{12})
+	if pretty, concat := schedule.PrettyString(), schedule.PrettyStringConcat(); pretty != concat {
+		t.Errorf("expected pretty string %q for a built schedule but received %q", concat, pretty)
+	}
+}
+
+func BenchmarkPrettyString(b *testing.B) {
+	schedule, err := cronschedule.Parse("0,15,30 9,17 * * 1-4")
+	if err != nil {
+		b.Fatalf("failed to build schedule: %s", err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = schedule.PrettyString()
+	}
+}
+
+func BenchmarkPrettyStringConcat(b *testing.B) {
+	schedule, err := cronschedule.Parse("0,15,30 9,17 * * 1-4")
+	if err != nil {
+		b.Fatalf("failed to build schedule: %s", err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = schedule.PrettyStringConcat()
+	}
+}
+
+func TestRestrictHours(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/5 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	restricted := schedule.RestrictHours(9, 17)
+	if str := restricted.String(); str != "*/5 9-17 * * *" {
+		t.Errorf("expected restricted schedule */5 9-17 * * * but received %s", str)
+	}
+
+	if restricted.ScheduleStr != "*/5 9-17 * * *" {
+		t.Errorf("expected restricted schedule string */5 9-17 * * * but received %s", restricted.ScheduleStr)
+	}
+
+	start := time.Date(2020, time.July, 23, 17, 52, 0, 0, time.Local)
+	expected := time.Date(2020, time.July, 23, 17, 55, 0, 0, time.Local)
+	if next := restricted.NextExecution(start); !next.Equal(expected) {
+		t.Errorf("expected next execution %v but received %v", expected, next)
+	}
+
+	expected = time.Date(2020, time.July, 24, 9, 0, 0, 0, time.Local)
+	if next := restricted.NextExecution(expected.Add(-8 * time.Hour)); !next.Equal(expected) {
+		t.Errorf("expected next execution %v but received %v", expected, next)
+	}
+
+	// The original schedule is left unchanged.
+	if str := schedule.String(); str != "*/5 * * * *" {
+		t.Errorf("expected original schedule to be unchanged but received %s", str)
+	}
+
+	// Only hours already in the schedule are kept.
+	evening, err := cronschedule.Parse("0 8,12,20 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if hours := evening.RestrictHours(9, 17).FieldValues(1); len(hours) != 1 || hours[0] != 12 {
+		t.Errorf("expected restricted hours [12] but received %v", hours)
+	}
+	if evening.RestrictHours(13, 19).IsSatisfiable() {
+		t.Errorf("expected schedule restricted to excluded hours not to be satisfiable")
+	}
+}
+
+func TestParseNicknames(t *testing.T) {
+	tests := []struct {
+		Nicknames []string
+		Expected  string
+	}{
+		{[]string{"@yearly", "@Yearly", "@ANNUALLY"}, "0 0 1 1 *"},
+		{[]string{"@monthly", "@MONTHLY"}, "0 0 1 * *"},
+		{[]string{"@weekly", "@WeeKLy"}, "0 0 * * 0"},
+		{[]string{"@daily", "@Daily", "@DAILY", "@midnight"}, "0 0 * * *"},
+		{[]string{"@hourly", "@HOURLY"}, "0 * * * *"},
+		{[]string{"@every 15m", "@EVERY 15m", "@Every 15M"}, "*/15 * * * *"},
+	}
+
+	for _, test := range tests {
+		for _, nickname := range test.Nicknames {
+			schedule, err := cronschedule.Parse(nickname)
+			if err != nil {
+				t.Errorf("failed to parse %s: %s", nickname, err)
+				continue
+			}
+
+			if str := schedule.String(); str != test.Expected {
+				t.Errorf("expected %s to be parsed as %s but received %s", nickname, test.Expected, str)
+			}
+
+			if schedule.ScheduleStr != nickname {
+				t.Errorf("expected ScheduleStr %s but received %s", nickname, schedule.ScheduleStr)
+			}
+		}
+	}
+
+	// Nicknames use POSIX numbering regardless of the day of week convention.
+	weekly, err := cronschedule.ParseWithOptions("@weekly", cronschedule.ParseOptions{DayOfWeek: cronschedule.DayOfWeekISO})
+	if err != nil {
+		t.Fatalf("failed to parse @weekly with ISO numbering: %s", err)
+	}
+	if str := weekly.String(); str != "0 0 * * 0" {
+		t.Errorf("expected @weekly to be parsed as 0 0 * * 0 but received %s", str)
+	}
+
+	if _, err := cronschedule.Parse("@fortnightly"); err == nil {
+		t.Errorf("expected error parsing unknown nickname")
+	}
+}
+
+func TestNextExecutionMonotonic(t *testing.T) {
+	schedule, err := cronschedule.Parse("* * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	now := time.Now()
+	next := schedule.NextExecution(now)
+
+	// A time with a monotonic clock reading includes it as m=±value when formatted.
+	if strings.Contains(next.String(), "m=") {
+		t.Errorf("expected next execution without a monotonic reading but received %s", next.String())
+	}
+
+	if d := next.Sub(now); d <= 0 || d > time.Minute {
+		t.Errorf("expected next execution within a minute of now but received %v", d)
+	}
+
+	if !next.Equal(now.Round(0).Truncate(time.Minute).Add(time.Minute)) {
+		t.Errorf("expected next execution at the start of the next minute but received %v", next)
+	}
+}
+
+func TestNextExecutionsLeapDay(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 0 29 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tests := []struct {
+		T        time.Time
+		Expected []time.Time
+	}{
+		{
+			T: time.Date(2023, time.January, 15, 0, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local),
+				time.Date(2028, time.February, 29, 0, 0, 0, 0, time.Local),
+				time.Date(2032, time.February, 29, 0, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			// Starting on February 28 of a non leap year.
+			T: time.Date(2023, time.February, 28, 12, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local),
+				time.Date(2028, time.February, 29, 0, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			// The year 2100 is not a leap year.
+			T: time.Date(2096, time.March, 1, 0, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2104, time.February, 29, 0, 0, 0, 0, time.Local),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		execTimes := schedule.NextExecutions(test.T, len(test.Expected))
+		for i := range test.Expected {
+			if !execTimes[i].Equal(test.Expected[i]) {
+				t.Errorf("expected %v at index %d from %v but received %v", test.Expected[i], i, test.T, execTimes[i])
+			}
+		}
+	}
+
+	// Only the 28th is available in non leap years.
+	endOfFebruary, err := cronschedule.Parse("0 0 28-31 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	expected := []time.Time{
+		time.Date(2023, time.February, 28, 0, 0, 0, 0, time.Local),
+		time.Date(2024, time.February, 28, 0, 0, 0, 0, time.Local),
+		time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local),
+		time.Date(2025, time.February, 28, 0, 0, 0, 0, time.Local),
+	}
+	execTimes := endOfFebruary.NextExecutions(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.Local), len(expected))
+	for i := range expected {
+		if !execTimes[i].Equal(expected[i]) {
+			t.Errorf("expected %v at index %d but received %v", expected[i], i, execTimes[i])
+		}
+	}
+}
+
+func TestNextExecutionsBucketed(t *testing.T) {
+	// Evaluating in UTC so the hour buckets align with the executions regardless of the local location.
+	schedule, err := cronschedule.Parse("CRON_TZ=UTC */10 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	start := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.UTC)
+	buckets := schedule.NextExecutionsBucketed(start, 3, time.Hour)
+
+	expected := []time.Time{
+		time.Date(2020, time.July, 23, 15, 0, 0, 0, time.UTC),
+		time.Date(2020, time.July, 23, 16, 0, 0, 0, time.UTC),
+		time.Date(2020, time.July, 23, 17, 0, 0, 0, time.UTC),
+	}
+	if len(buckets) != len(expected) {
+		t.Fatalf("expected %d buckets but received %d: %v", len(expected), len(buckets), buckets)
+	}
+
+	for i := range expected {
+		if !buckets[i].Equal(expected[i]) {
+			t.Errorf("expected bucket %v at index %d but received %v", expected[i], i, buckets[i])
+		}
+	}
+
+	// A schedule that never executes has no buckets.
+	unsatisfiable, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if buckets := unsatisfiable.NextExecutionsBucketed(start, 3, time.Hour); len(buckets) != 0 {
+		t.Errorf("expected no buckets for a schedule that never executes but received %v", buckets)
+	}
+}
+
+func TestNextExecutionsWithSeconds(t *testing.T) {
+	// The input carries 45 seconds which must never result in an execution within the same minute.
+	start := time.Date(2023, time.June, 15, 10, 30, 45, 0, time.Local)
+
+	tests := []struct {
+		Schedule string
+		Expected []time.Time
+	}{
+		{"* * * * *", []time.Time{
+			time.Date(2023, time.June, 15, 10, 31, 0, 0, time.Local),
+			time.Date(2023, time.June, 15, 10, 32, 0, 0, time.Local),
+		}},
+		{"30 * * * *", []time.Time{
+			time.Date(2023, time.June, 15, 11, 30, 0, 0, time.Local),
+			time.Date(2023, time.June, 15, 12, 30, 0, 0, time.Local),
+		}},
+		{"31 10 * * *", []time.Time{
+			time.Date(2023, time.June, 15, 10, 31, 0, 0, time.Local),
+			time.Date(2023, time.June, 16, 10, 31, 0, 0, time.Local),
+		}},
+		{"30 10 15 * *", []time.Time{
+			time.Date(2023, time.July, 15, 10, 30, 0, 0, time.Local),
+			time.Date(2023, time.August, 15, 10, 30, 0, 0, time.Local),
+		}},
+		{"*/15 10 * * 4", []time.Time{
+			time.Date(2023, time.June, 15, 10, 45, 0, 0, time.Local),
+			time.Date(2023, time.June, 22, 10, 0, 0, 0, time.Local),
+		}},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		execTimes := schedule.NextExecutions(start, len(test.Expected))
+		for i := range test.Expected {
+			if !execTimes[i].Equal(test.Expected[i]) {
+				t.Errorf("expected %v at index %d for %s but received %v", test.Expected[i], i, test.Schedule, execTimes[i])
+			}
+		}
+	}
+}
+
+func TestNextExecutionsMondayAcrossYear(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 9 * * 1")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	tests := []struct {
+		T        time.Time
+		Expected []time.Time
+	}{
+		{
+			// Saturday December 30 with the first Monday falling on the first day of the next year.
+			T: time.Date(2023, time.December, 30, 12, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2024, time.January, 1, 9, 0, 0, 0, time.Local),
+				time.Date(2024, time.January, 8, 9, 0, 0, 0, time.Local),
+				time.Date(2024, time.January, 15, 9, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			// Monday December 30 before the execution.
+			T: time.Date(2024, time.December, 30, 8, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2024, time.December, 30, 9, 0, 0, 0, time.Local),
+				time.Date(2025, time.January, 6, 9, 0, 0, 0, time.Local),
+				time.Date(2025, time.January, 13, 9, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			// Monday December 30 after the execution.
+			T: time.Date(2024, time.December, 30, 9, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2025, time.January, 6, 9, 0, 0, 0, time.Local),
+				time.Date(2025, time.January, 13, 9, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			// Tuesday December 31 at the last minute of the year.
+			T: time.Date(2024, time.December, 31, 23, 59, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2025, time.January, 6, 9, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			// Wednesday January 31 crossing into February.
+			T: time.Date(2024, time.January, 31, 9, 0, 0, 0, time.Local),
+			Expected: []time.Time{
+				time.Date(2024, time.February, 5, 9, 0, 0, 0, time.Local),
+				time.Date(2024, time.February, 12, 9, 0, 0, 0, time.Local),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		execTimes := schedule.NextExecutions(test.T, len(test.Expected))
+		if len(execTimes) != len(test.Expected) {
+			t.Errorf("expected %d executions from %v but received %d", len(test.Expected), test.T, len(execTimes))
+			continue
+		}
+		for i := range test.Expected {
+			if !execTimes[i].Equal(test.Expected[i]) {
+				t.Errorf("expected %v at index %d from %v but received %v", test.Expected[i], i, test.T, execTimes[i])
+			}
+		}
+	}
+}
+
+func TestParseNamed(t *testing.T) {
+	tests := []struct {
+		Named    string
+		Schedule string
+		Error    bool
+	}{
+		{"minute=0 hour=22 dom=* month=* dow=1-5", "0 22 * * 1-5", false},
+		{"dow=1-5 minute=0 hour=22", "0 22 * * 1-5", false},
+		{"Minute=*/15", "*/15 * * * *", false},
+		{"hour=9 month=jan,jul dom=1", "* 9 1 1,7 *", false},
+		{"minute=0 hour=9 dow=5#1", "0 9 * * 5#1", false},
+		{"", "* * * * *", false},
+		{"minute=0 minute=5", "", true},
+		{"second=0", "", true},
+		{"minute", "", true},
+		{"minute=", "", true},
+		{"hour=24", "", true},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.ParseNamed(test.Named)
+		switch {
+		case test.Error && err == nil:
+			t.Errorf("expected an error for [%s] but received %s", test.Named, schedule.String())
+			continue
+		case !test.Error && err != nil:
+			t.Errorf("expected [%s] to parse but received %s", test.Named, err)
+			continue
+		case test.Error:
+			continue
+		}
+
+		expected, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+		if !schedule.Equal(&expected) {
+			t.Errorf("expected %s for [%s] but received %s", test.Schedule, test.Named, schedule.String())
+		}
+	}
+}
+
+func TestFiresAtLeastOnceBetween(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Start    time.Time
+		End      time.Time
+		Expected bool
+	}{
+		// A monthly schedule does not fire within a week that excludes the first of the month.
+		{"0 0 1 * *", time.Date(2020, time.March, 10, 0, 0, 0, 0, time.Local), time.Date(2020, time.March, 17, 0, 0, 0, 0, time.Local), false},
+		{"0 0 1 * *", time.Date(2020, time.March, 28, 0, 0, 0, 0, time.Local), time.Date(2020, time.April, 4, 0, 0, 0, 0, time.Local), true},
+		{"30 2 * * *", time.Date(2020, time.March, 10, 12, 0, 0, 0, time.Local), time.Date(2020, time.March, 11, 12, 0, 0, 0, time.Local), true},
+		{"0 9 * * 1-5", time.Date(2020, time.March, 14, 0, 0, 0, 0, time.Local), time.Date(2020, time.March, 16, 0, 0, 0, 0, time.Local), false},
+		// The start is included while the end is excluded.
+		{"0 0 1 * *", time.Date(2020, time.March, 1, 0, 0, 0, 0, time.Local), time.Date(2020, time.March, 1, 0, 1, 0, 0, time.Local), true},
+		{"0 0 1 * *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.Local), time.Date(2020, time.March, 1, 0, 0, 0, 0, time.Local), false},
+		{"0 0 31 2 *", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.Local), time.Date(2030, time.January, 1, 0, 0, 0, 0, time.Local), false},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if fires := schedule.FiresAtLeastOnceBetween(test.Start, test.End); fires != test.Expected {
+			t.Errorf("expected %t for %s between %v and %v but received %t", test.Expected, test.Schedule, test.Start, test.End, fires)
+		}
+	}
+}
+
+func TestStringCache(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "0 22 * * 1-5", "*/5 1-3 1,15 1-6 0#2", "CRON_TZ=UTC 0 9 * * *"} {
+		schedule, err := cronschedule.Parse(expr)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", expr, err)
+			continue
+		}
+
+		if cached, fresh := schedule.String(), schedule.CanonicalString(); cached != fresh {
+			t.Errorf("expected cached string %s for %s to match %s", cached, expr, fresh)
+		}
+	}
+
+	schedule, err := cronschedule.Parse("0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// Adding values is reflected before the schedule is rebuilt.
+	schedule.AddMinutes([]int{30})
+	if str := schedule.String(); str != "0,30 22 * * 1-5" {
+		t.Errorf("expected 0,30 22 * * 1-5 after adding a minute but received %s", str)
+	}
+	schedule.AddNthDaysOfTheWeek([]cronschedule.NthWeekday{{Weekday: time.Sunday, N: 1}})
+	schedule.Rebuild()
+	if str := schedule.String(); str != "0,30 22 * * 1-5,0#1" {
+		t.Errorf("expected 0,30 22 * * 1-5,0#1 after adding an occurrence but received %s", str)
+	}
+
+	// Changing the location is reflected without rebuilding the schedule.
+	schedule.Location = time.UTC
+	if str := schedule.String(); str != "CRON_TZ=UTC 0,30 22 * * 1-5,0#1" {
+		t.Errorf("expected the location to be included but received %s", str)
+	}
+	if cached, fresh := schedule.String(), schedule.CanonicalString(); cached != fresh {
+		t.Errorf("expected cached string %s to match %s", cached, fresh)
+	}
+
+	// Schedules with a location use the cache whether it was set by a CRON_TZ token or WithLocation.
+	zoned, err := cronschedule.Parse("CRON_TZ=UTC 0 9 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	withLocation, err := cronschedule.NewSchedule("0 9 * * *", cronschedule.WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	for _, schedule := range []cronschedule.Schedule{zoned, withLocation} {
+		if !schedule.StringCached() {
+			t.Errorf("expected the string of %s to be cached", schedule.String())
+		}
+		if first, second := schedule.String(), schedule.String(); first != "CRON_TZ=UTC 0 9 * * *" || second != first {
+			t.Errorf("expected CRON_TZ=UTC 0 9 * * * from both calls but received %s and %s", first, second)
+		}
+		if !schedule.StringCached() {
+			t.Errorf("expected the string of %s to remain cached", schedule.String())
+		}
+	}
+}
+
+func TestNextExecutionsStrictlyAfter(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 22 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	expected := []time.Time{
+		time.Date(2020, time.July, 24, 22, 0, 0, 0, time.Local),
+		time.Date(2020, time.July, 25, 22, 0, 0, 0, time.Local),
+	}
+
+	// The minute of the provided time is never returned regardless of its seconds.
+	for _, start := range []time.Time{
+		time.Date(2020, time.July, 23, 22, 0, 0, 0, time.Local),
+		time.Date(2020, time.July, 23, 22, 0, 0, 1, time.Local),
+		time.Date(2020, time.July, 23, 22, 0, 59, 999999999, time.Local),
+	} {
+		execTimes := schedule.NextExecutions(start, len(expected))
+		for i := range expected {
+			if !execTimes[i].Equal(expected[i]) {
+				t.Errorf("expected %v at index %d from %v but received %v", expected[i], i, start, execTimes[i])
+			}
+		}
+
+		if next := schedule.NextExecution(start); !next.Equal(expected[0]) {
+			t.Errorf("expected next execution %v from %v but received %v", expected[0], start, next)
+		}
+	}
+
+	// The minute before still returns the execution of the same day.
+	start := time.Date(2020, time.July, 23, 21, 59, 59, 0, time.Local)
+	if next := schedule.NextExecution(start); !next.Equal(time.Date(2020, time.July, 23, 22, 0, 0, 0, time.Local)) {
+		t.Errorf("expected the execution of the same day from %v but received %v", start, next)
+	}
+}
+
+func TestHasOverlap(t *testing.T) {
+	from := time.Date(2020, time.July, 23, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		Schedule string
+		Duration time.Duration
+		Samples  int
+		Expected bool
+	}{
+		{"* * * * *", 90 * time.Second, 10, true},
+		{"* * * * *", time.Minute, 10, false},
+		{"*/5 * * * *", 4 * time.Minute, 10, false},
+		{"0,50 * * * *", 20 * time.Minute, 10, true},
+		// Executions exactly the job duration apart do not overlap.
+		{"0,50 * * * *", 10 * time.Minute, 10, false},
+		{"0 22 * * 1-5", time.Hour, 10, false},
+		{"0 22 * * 1-5", 25 * time.Hour, 10, true},
+		{"* * * * *", 90 * time.Second, 1, false},
+		{"0 0 31 2 *", time.Hour, 10, false},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if overlap := schedule.HasOverlap(test.Duration, from, test.Samples); overlap != test.Expected {
+			t.Errorf("expected overlap %t for %s with a %s job over %d samples but received %t", test.Expected, test.Schedule, test.Duration, test.Samples, overlap)
+		}
+	}
+}
+
+func TestMinuteHourGrid(t *testing.T) {
+	schedule, err := cronschedule.Parse("0,30 9-11 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	grid := schedule.MinuteHourGrid()
+	for hour, minutes := range grid {
+		if minutes == nil {
+			t.Errorf("expected a non nil slice for hour %d", hour)
+			continue
+		}
+
+		if hour < 9 || hour > 11 {
+			if len(minutes) != 0 {
+				t.Errorf("expected no minutes for hour %d but received %v", hour, minutes)
+			}
+			continue
+		}
+
+		if len(minutes) != 2 || minutes[0] != 0 || minutes[1] != 30 {
+			t.Errorf("expected minutes [0 30] for hour %d but received %v", hour, minutes)
+		}
+	}
+
+	// Each row is independent of the others.
+	grid[9][0] = 15
+	if grid[10][0] != 0 {
+		t.Errorf("expected modifying one hour not to modify another")
+	}
+}
+
+func TestParseOrDefault(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Fallback string
+		Expected string
+	}{
+		{"0 22 * * 1-5", "0 0 * * *", "0 22 * * 1-5"},
+		{"0 22 * * 9", "0 0 * * *", "0 0 * * *"},
+		{"", "@hourly", "0 * * * *"},
+	}
+
+	for _, test := range tests {
+		schedule := cronschedule.ParseOrDefault(test.Schedule, test.Fallback)
+		if str := schedule.String(); str != test.Expected {
+			t.Errorf("expected %s for [%s] with fallback %s but received %s", test.Expected, test.Schedule, test.Fallback, str)
+		}
+	}
+
+	// A valid schedule does not require a valid fallback.
+	schedule := cronschedule.ParseOrDefault("0 22 * * *", "invalid")
+	if str := schedule.String(); str != "0 22 * * *" {
+		t.Errorf("expected 0 22 * * * but received %s", str)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic when the fallback is invalid")
+		}
+	}()
+	cronschedule.ParseOrDefault("invalid", "also invalid")
+}
@@ -0,0 +1,152 @@
+package cronschedule
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CrontabLine is a crontab line parsed by ParseLine. The spacing of the original line is retained so the line may be
+// re-emitted by String with only the parts changed by the caller differing from Raw.
+type CrontabLine struct {
+	// Raw is the original line provided to ParseLine.
+	Raw string
+
+	// Schedule is the schedule parsed from Expression.
+	Schedule Schedule
+
+	// Expression is the schedule portion of the line as written, including any CRON_TZ token.
+	Expression string
+
+	// Command is the command executed on the schedule without surrounding whitespace.
+	Command string
+
+	// Comment is the trailing comment, including the leading #, if one was present.
+	Comment string
+
+	indent           string
+	separator        string
+	commentSeparator string
+}
+
+// String re-emits the line using the original spacing.
+func (l *CrontabLine) String() string {
+	return l.indent + l.Expression + l.separator + l.Command + l.commentSeparator + l.Comment
+}
+
+// SetSchedule replaces the schedule of the line with the schedule _expr_ while leaving the command, comment, and
+// spacing around them unchanged so String emits the edited line. The fields of _expr_ may be separated by any amount of
+// whitespace the same as ParseLine. Raw is not changed. The line is left unchanged if _expr_ fails to parse.
+func (l *CrontabLine) SetSchedule(expr string) error {
+	expr = strings.TrimSpace(expr)
+	schedule, err := Parse(strings.Join(strings.Fields(expr), " "))
+	if err != nil {
+		return fmt.Errorf("failed to parse schedule: %s", err)
+	}
+
+	l.Schedule = schedule
+	l.Expression = expr
+	return nil
+}
+
+// ParseLine parses a crontab line containing a schedule followed by a command and an optional trailing comment. A
+// trailing comment starts with a # preceded by whitespace. Fields of the schedule may be separated by any amount of
+// whitespace. An error is provided if the schedule fails to parse or the line contains only a comment.
+func ParseLine(line string) (CrontabLine, error) {
+	crontabLine := CrontabLine{Raw: line}
+
+	tokens := tokenIndexes(line)
+	if len(tokens) == 0 || line[tokens[0][0]] == '#' {
+		return crontabLine, fmt.Errorf("line does not contain a schedule")
+	}
+
+	// Determining how many tokens make up the schedule. A CRON_TZ token may lead the schedule which is then either an
+	// @every nickname and its duration, another nickname, or the five fields.
+	scheduleTokens := 0
+	if strings.HasPrefix(tokenAt(line, tokens, scheduleTokens), cronTZPrefix) {
+		scheduleTokens++
+	}
+	switch first := tokenAt(line, tokens, scheduleTokens); {
+	case strings.EqualFold(first+" ", everyPrefix):
+		scheduleTokens += 2
+	case strings.HasPrefix(first, "@"):
+		scheduleTokens++
+	default:
+		scheduleTokens += 5
+	}
+	if scheduleTokens > len(tokens) {
+		scheduleTokens = len(tokens)
+	}
+
+	fields := make([]string, 0, scheduleTokens)
+	for i := 0; i < scheduleTokens; i++ {
+		fields = append(fields, tokenAt(line, tokens, i))
+	}
+
+	schedule, err := Parse(strings.Join(fields, " "))
+	if err != nil {
+		return crontabLine, fmt.Errorf("failed to parse schedule of line: %s", err)
+	}
+	crontabLine.Schedule = schedule
+
+	scheduleEnd := tokens[scheduleTokens-1][1]
+	crontabLine.indent = line[:tokens[0][0]]
+	crontabLine.Expression = line[tokens[0][0]:scheduleEnd]
+
+	// Splitting the remainder of the line into the command and trailing comment.
+	remainder := line[scheduleEnd:]
+	if scheduleTokens < len(tokens) {
+		crontabLine.separator = line[scheduleEnd:tokens[scheduleTokens][0]]
+		remainder = line[tokens[scheduleTokens][0]:]
+	}
+
+	command := remainder
+	for i := 0; i < len(remainder); i++ {
+		if remainder[i] == '#' && (i == 0 || unicode.IsSpace(rune(remainder[i-1]))) {
+			command = remainder[:i]
+			crontabLine.Comment = remainder[i:]
+			break
+		}
+	}
+
+	crontabLine.Command = strings.TrimRightFunc(command, unicode.IsSpace)
+	crontabLine.commentSeparator = command[len(crontabLine.Command):]
+	if crontabLine.Command == "" && crontabLine.Comment != "" {
+		// Without a command the whitespace before the comment was captured as the separator.
+		crontabLine.commentSeparator = crontabLine.separator
+		crontabLine.separator = ""
+	}
+
+	return crontabLine, nil
+}
+
+// tokenIndexes returns the start and end indexes of each whitespace separated token in the line.
+func tokenIndexes(line string) [][2]int {
+	tokens := make([][2]int, 0, 8)
+	start := -1
+	for i, r := range line {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				tokens = append(tokens, [2]int{start, i})
+				start = -1
+			}
+			continue
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, [2]int{start, len(line)})
+	}
+	return tokens
+}
+
+// tokenAt returns the token at index i or an empty string if there is no such token.
+func tokenAt(line string, tokens [][2]int, i int) string {
+	if i >= len(tokens) {
+		return ""
+	}
+	return line[tokens[i][0]:tokens[i][1]]
+}
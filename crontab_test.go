@@ -0,0 +1,134 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"strings"
+	"testing"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		Line       string
+		Expression string
+		Command    string
+		Comment    string
+	}{
+		{
+			Line:       "  0  22 * * 1-5\t/usr/bin/backup --full   # nightly backup",
+			Expression: "0  22 * * 1-5",
+			Command:    "/usr/bin/backup --full",
+			Comment:    "# nightly backup",
+		},
+		{
+			Line:       "*/5 * * * * echo 'a#b' > /tmp/out",
+			Expression: "*/5 * * * *",
+			Command:    "echo 'a#b' > /tmp/out",
+		},
+		{
+			Line:       "CRON_TZ=UTC @every 15m  /usr/bin/poll",
+			Expression: "CRON_TZ=UTC @every 15m",
+			Command:    "/usr/bin/poll",
+		},
+		{
+			Line:       "0 0 1 * * # schedule only",
+			Expression: "0 0 1 * *",
+			Comment:    "# schedule only",
+		},
+	}
+
+	for _, test := range tests {
+		line, err := cronschedule.ParseLine(test.Line)
+		if err != nil {
+			t.Errorf("failed to parse line [%s]: %s", test.Line, err)
+			continue
+		}
+
+		if line.Expression != test.Expression {
+			t.Errorf("expected expression [%s] but received [%s]", test.Expression, line.Expression)
+		}
+
+		if line.Command != test.Command {
+			t.Errorf("expected command [%s] but received [%s]", test.Command, line.Command)
+		}
+
+		if line.Comment != test.Comment {
+			t.Errorf("expected comment [%s] but received [%s]", test.Comment, line.Comment)
+		}
+
+		if str := line.String(); str != test.Line {
+			t.Errorf("expected line to be re-emitted verbatim as [%s] but received [%s]", test.Line, str)
+		}
+	}
+
+	for _, invalid := range []string{"", "# comment only", "0 22 * * 9 /usr/bin/backup"} {
+		if _, err := cronschedule.ParseLine(invalid); err == nil {
+			t.Errorf("expected error parsing line [%s]", invalid)
+		}
+	}
+}
+
+func TestCrontabLineSetSchedule(t *testing.T) {
+	tests := []struct {
+		Line       string
+		Expression string
+		Expected   string
+	}{
+		{
+			Line:       "  0  22 * * 1-5\t/usr/bin/backup --full   # nightly backup",
+			Expression: "30 1 * * 0",
+			Expected:   "  30 1 * * 0\t/usr/bin/backup --full   # nightly backup",
+		},
+		{
+			Line:       "*/5 * * * * echo 'a#b' > /tmp/out",
+			Expression: "CRON_TZ=UTC @hourly",
+			Expected:   "CRON_TZ=UTC @hourly echo 'a#b' > /tmp/out",
+		},
+		{
+			Line:       "0 0 1 * * # schedule only",
+			Expression: " 0 12  15 * * ",
+			Expected:   "0 12  15 * * # schedule only",
+		},
+	}
+
+	for _, test := range tests {
+		line, err := cronschedule.ParseLine(test.Line)
+		if err != nil {
+			t.Errorf("failed to parse line [%s]: %s", test.Line, err)
+			continue
+		}
+		command := line.Command
+
+		if err := line.SetSchedule(test.Expression); err != nil {
+			t.Errorf("failed to set schedule [%s]: %s", test.Expression, err)
+			continue
+		}
+
+		if line.Command != command {
+			t.Errorf("expected command [%s] to be preserved but received [%s]", command, line.Command)
+		}
+		if str := line.String(); str != test.Expected {
+			t.Errorf("expected line [%s] but received [%s]", test.Expected, str)
+		}
+
+		expected, err := cronschedule.Parse(strings.Join(strings.Fields(test.Expression), " "))
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Expression, err)
+			continue
+		}
+		if !line.Schedule.Equal(&expected) {
+			t.Errorf("expected schedule %s but received %s", expected.String(), line.Schedule.String())
+		}
+	}
+
+	// An invalid schedule leaves the line unchanged.
+	line, err := cronschedule.ParseLine("0 22 * * 1-5 /usr/bin/backup")
+	if err != nil {
+		t.Fatalf("failed to parse line: %s", err)
+	}
+	if err := line.SetSchedule("0 22 * * 9"); err == nil {
+		t.Errorf("expected an error setting an invalid schedule")
+	}
+	if str := line.String(); str != "0 22 * * 1-5 /usr/bin/backup" {
+		t.Errorf("expected the line to be unchanged but received [%s]", str)
+	}
+}
@@ -0,0 +1,162 @@
+package cronschedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// describeTimesMax is the largest number of minute and hour combinations described as a list of times rather than as
+// separate minutes and hours.
+const describeTimesMax = 4
+
+// Describer generates human readable descriptions of schedules using its name tables and phrases. Every field may be
+// replaced to describe schedules in a language other than English, which is most easily done by starting from
+// EnglishDescriber. Each phrase is a format string receiving the values described as a single string.
+type Describer struct {
+	// MonthNames contains the name of each month starting with January.
+	MonthNames [12]string
+
+	// WeekdayNames contains the name of each day of the week starting with Sunday.
+	WeekdayNames [7]string
+
+	// Ordinals contains the words describing the occurrences of a day of the week within a month starting with the
+	// first.
+	Ordinals [5]string
+
+	// EveryMinute describes a schedule executing every minute, e.g. every minute.
+	EveryMinute string
+
+	// AtTimes describes a list of times formatted as 15:04, e.g. at %s.
+	AtTimes string
+
+	// AtMinutes describes a list of minutes, e.g. at minute %s.
+	AtMinutes string
+
+	// DuringHours describes a list of hours, e.g. during hour %s.
+	DuringHours string
+
+	// OnDaysOfMonth describes a list of days of the month, e.g. on day %s of the month.
+	OnDaysOfMonth string
+
+	// OnDaysOfWeek describes a list of days of the week and occurrences of them, e.g. on %s.
+	OnDaysOfWeek string
+
+	// NthWeekday describes an occurrence of a day of the week receiving the ordinal followed by the day of the week,
+	// e.g. the %s %s of the month.
+	NthWeekday string
+
+	// Or joins the days of the month and days of the week when both are specified.
+	Or string
+
+	// InMonths describes a list of months, e.g. in %s.
+	InMonths string
+
+	// ListSeparator separates the values of a list.
+	ListSeparator string
+}
+
+// EnglishDescriber returns the Describer used by Schedule.Describe generating English descriptions.
+func EnglishDescriber() Describer {
+	return Describer{
+		MonthNames: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September",
+			"October", "November", "December"},
+		WeekdayNames:  [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		Ordinals:      [5]string{"first", "second", "third", "fourth", "fifth"},
+		EveryMinute:   "every minute",
+		AtTimes:       "at %s",
+		AtMinutes:     "at minute %s",
+		DuringHours:   "during hour %s",
+		OnDaysOfMonth: "on day %s of the month",
+		OnDaysOfWeek:  "on %s",
+		NthWeekday:    "the %s %s of the month",
+		Or:            "or",
+		InMonths:      "in %s",
+		ListSeparator: ", ",
+	}
+}
+
+// Describe generates an English description of the schedule such as "At 22:00 on Monday, Tuesday, Wednesday,
+// Thursday, Friday" for 0 22 * * 1-5. Use a Describer to generate descriptions in other languages.
+func (s *Schedule) Describe() string {
+	return EnglishDescriber().Describe(s)
+}
+
+// Describe generates a description of the schedule _s_ using the names and phrases of the describer. The times of day
+// are described first followed by the days and then the months, with the first letter capitalized. Fields containing
+// every value are not described and the location of the schedule, if any, is appended in parentheses.
+func (d Describer) Describe(s *Schedule) string {
+	parts := make([]string, 0, 5)
+
+	minutes := sortMapKeys(s.Minutes)
+	hours := sortMapKeys(s.Hours)
+	switch {
+	case s.IsWildcard(0) && s.IsWildcard(1):
+		parts = append(parts, d.EveryMinute)
+	case !s.IsWildcard(0) && !s.IsWildcard(1) && len(minutes)*len(hours) <= describeTimesMax:
+		times := make([]string, 0, len(minutes)*len(hours))
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				times = append(times, fmt.Sprintf("%02d:%02d", hour, minute))
+			}
+		}
+		parts = append(parts, fmt.Sprintf(d.AtTimes, strings.Join(times, d.ListSeparator)))
+	default:
+		if s.IsWildcard(0) {
+			parts = append(parts, d.EveryMinute)
+		} else {
+			parts = append(parts, fmt.Sprintf(d.AtMinutes, d.joinInts(minutes)))
+		}
+		if !s.IsWildcard(1) {
+			parts = append(parts, fmt.Sprintf(d.DuringHours, d.joinInts(hours)))
+		}
+	}
+
+	if !s.includesEveryDay() {
+		days := make([]string, 0, 2)
+		if len(s.DaysOfMonth) != 0 {
+			days = append(days, fmt.Sprintf(d.OnDaysOfMonth, d.joinInts(sortMapKeys(s.DaysOfMonth))))
+		}
+		if s.hasDayOfWeek() {
+			weekdays := make([]string, 0, len(s.DaysOfTheWeek)+len(s.NthDaysOfTheWeek))
+			for _, day := range sortMapKeys(s.DaysOfTheWeek) {
+				weekdays = append(weekdays, d.WeekdayNames[day])
+			}
+			for _, nth := range s.NthDaysOfTheWeek {
+				weekdays = append(weekdays, fmt.Sprintf(d.NthWeekday, d.Ordinals[nth.N-1], d.WeekdayNames[nth.Weekday]))
+			}
+			days = append(days, fmt.Sprintf(d.OnDaysOfWeek, strings.Join(weekdays, d.ListSeparator)))
+		}
+		parts = append(parts, strings.Join(days, " "+d.Or+" "))
+	}
+
+	if !s.IsWildcard(3) {
+		months := make([]string, 0, len(s.Months))
+		for _, month := range sortMapKeys(s.Months) {
+			months = append(months, d.MonthNames[month-1])
+		}
+		parts = append(parts, fmt.Sprintf(d.InMonths, strings.Join(months, d.ListSeparator)))
+	}
+
+	if s.Location != nil {
+		parts = append(parts, "("+s.Location.String()+")")
+	}
+
+	description := strings.Join(parts, " ")
+	if description == "" {
+		return description
+	}
+	first, size := utf8.DecodeRuneInString(description)
+	return string(unicode.ToUpper(first)) + description[size:]
+}
+
+// joinInts joins the _values_ with the list separator of the describer.
+func (d Describer) joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = strconv.Itoa(value)
+	}
+	return strings.Join(strs, d.ListSeparator)
+}
@@ -0,0 +1,86 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		Schedule    string
+		Description string
+	}{
+		{"* * * * *", "Every minute"},
+		{"0 22 * * 1-5", "At 22:00 on Monday, Tuesday, Wednesday, Thursday, Friday"},
+		{"0,30 9,17 * * *", "At 09:00, 09:30, 17:00, 17:30"},
+		{"*/15 9-11 * * *", "At minute 0, 15, 30, 45 during hour 9, 10, 11"},
+		{"* 3 * * *", "Every minute during hour 3"},
+		{"0 0 1 1 *", "At 00:00 on day 1 of the month in January"},
+		{"0 9 * * 1,5#1", "At 09:00 on Monday, the first Friday of the month"},
+		{"0 9 1,15 * 1", "At 09:00 on day 1, 15 of the month or on Monday"},
+		{"CRON_TZ=UTC 30 6 * 6-8 *", "At 06:30 in June, July, August (UTC)"},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if description := schedule.Describe(); description != test.Description {
+			t.Errorf("expected description [%s] for %s but received [%s]", test.Description, test.Schedule, description)
+		}
+	}
+}
+
+func TestDescriberLocalized(t *testing.T) {
+	german := cronschedule.Describer{
+		MonthNames: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September",
+			"Oktober", "November", "Dezember"},
+		WeekdayNames:  [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		Ordinals:      [5]string{"ersten", "zweiten", "dritten", "vierten", "fünften"},
+		EveryMinute:   "jede Minute",
+		AtTimes:       "um %s",
+		AtMinutes:     "in Minute %s",
+		DuringHours:   "während Stunde %s",
+		OnDaysOfMonth: "am Tag %s des Monats",
+		OnDaysOfWeek:  "am %s",
+		NthWeekday:    "%s %s des Monats",
+		Or:            "oder",
+		InMonths:      "im %s",
+		ListSeparator: ", ",
+	}
+
+	tests := []struct {
+		Schedule    string
+		Description string
+	}{
+		{"0 22 * * 1-5", "Um 22:00 am Montag, Dienstag, Mittwoch, Donnerstag, Freitag"},
+		{"* * 1 3 *", "Jede Minute am Tag 1 des Monats im März"},
+		{"0 9 * * 5#1", "Um 09:00 am ersten Freitag des Monats"},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if description := german.Describe(&schedule); description != test.Description {
+			t.Errorf("expected description [%s] for %s but received [%s]", test.Description, test.Schedule, description)
+		}
+	}
+
+	// Replacing a single table of the English describer keeps the remaining English phrases.
+	describer := cronschedule.EnglishDescriber()
+	describer.WeekdayNames = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	schedule, err := cronschedule.Parse("0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if description := describer.Describe(&schedule); description != "At 22:00 on Mon, Tue, Wed, Thu, Fri" {
+		t.Errorf("expected abbreviated day names but received [%s]", description)
+	}
+}
@@ -0,0 +1,48 @@
+package cronschedule
+
+import "fmt"
+
+// EveryHourAt builds a schedule executing every hour at _minute_ past the hour, the same as parsing minute * * * *.
+// An error is provided if _minute_ is not a valid minute.
+func EveryHourAt(minute int) (Schedule, error) {
+	if minute < FieldMinuteMin || minute > FieldMinuteMax {
+		return EmptySchedule(), fmt.Errorf("minute %d is not between %d and %d", minute, FieldMinuteMin, FieldMinuteMax)
+	}
+
+	return everyDayAt([]int{minute}, valuesBetween(FieldHourMin, FieldHourMax)), nil
+}
+
+// EveryDayAt builds a schedule executing every day at _hour_:_minute_, the same as parsing minute hour * * *. An error
+// is provided if _hour_ or _minute_ is not valid.
+func EveryDayAt(hour int, minute int) (Schedule, error) {
+	if hour < FieldHourMin || hour > FieldHourMax {
+		return EmptySchedule(), fmt.Errorf("hour %d is not between %d and %d", hour, FieldHourMin, FieldHourMax)
+	}
+	if minute < FieldMinuteMin || minute > FieldMinuteMax {
+		return EmptySchedule(), fmt.Errorf("minute %d is not between %d and %d", minute, FieldMinuteMin, FieldMinuteMax)
+	}
+
+	return everyDayAt([]int{minute}, []int{hour}), nil
+}
+
+// everyDayAt builds a schedule executing every day at the _minutes_ and _hours_. The day of week field is cleared the
+// same as Parse clears it when both day fields are *.
+func everyDayAt(minutes []int, hours []int) Schedule {
+	schedule := EmptySchedule()
+	schedule.AddMinutes(minutes)
+	schedule.AddHours(hours)
+	schedule.AddDaysOfMonth(valuesBetween(FieldDayOfMonthMin, FieldDayOfMonthMax))
+	schedule.AddMonths(valuesBetween(FieldMonthMin, FieldMonthMax))
+	schedule.DaysOfTheWeekCleared = true
+	schedule.NormalizeStrings()
+	return schedule
+}
+
+// valuesBetween returns every value from _min_ to _max_ inclusive.
+func valuesBetween(min int, max int) []int {
+	values := make([]int, 0, max-min+1)
+	for i := min; i <= max; i++ {
+		values = append(values, i)
+	}
+	return values
+}
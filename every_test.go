@@ -0,0 +1,81 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+	"time"
+)
+
+func TestEveryHourAt(t *testing.T) {
+	schedule, err := cronschedule.EveryHourAt(5)
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if schedule.String() != "5 * * * *" || schedule.ScheduleStr != "5 * * * *" {
+		t.Errorf("expected 5 * * * * but received %s and %s", schedule.String(), schedule.ScheduleStr)
+	}
+
+	expected, err := cronschedule.Parse("5 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	if !schedule.Equal(&expected) {
+		t.Errorf("expected the schedule to equal the parsed 5 * * * *")
+	}
+
+	execTimes := schedule.NextExecutions(time.Date(2020, time.December, 31, 23, 30, 0, 0, time.Local), 2)
+	expectedTimes := []time.Time{
+		time.Date(2021, time.January, 1, 0, 5, 0, 0, time.Local),
+		time.Date(2021, time.January, 1, 1, 5, 0, 0, time.Local),
+	}
+	for i := range expectedTimes {
+		if !execTimes[i].Equal(expectedTimes[i]) {
+			t.Errorf("expected %v at index %d but received %v", expectedTimes[i], i, execTimes[i])
+		}
+	}
+
+	for _, minute := range []int{-1, 60} {
+		if _, err := cronschedule.EveryHourAt(minute); err == nil {
+			t.Errorf("expected an error for minute %d", minute)
+		}
+	}
+}
+
+func TestEveryDayAt(t *testing.T) {
+	schedule, err := cronschedule.EveryDayAt(22, 30)
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if schedule.String() != "30 22 * * *" {
+		t.Errorf("expected 30 22 * * * but received %s", schedule.String())
+	}
+
+	if !schedule.ShouldExecute(time.Date(2020, time.February, 29, 22, 30, 0, 0, time.Local)) {
+		t.Errorf("expected the schedule to execute at 22:30")
+	}
+	if schedule.ShouldExecute(time.Date(2020, time.February, 29, 22, 31, 0, 0, time.Local)) {
+		t.Errorf("expected the schedule not to execute at 22:31")
+	}
+
+	next := schedule.NextExecution(time.Date(2020, time.February, 29, 22, 30, 0, 0, time.Local))
+	if expected := time.Date(2020, time.March, 1, 22, 30, 0, 0, time.Local); !next.Equal(expected) {
+		t.Errorf("expected %v but received %v", expected, next)
+	}
+
+	tests := []struct {
+		Hour   int
+		Minute int
+	}{
+		{-1, 0},
+		{24, 0},
+		{0, -1},
+		{0, 60},
+	}
+	for _, test := range tests {
+		if _, err := cronschedule.EveryDayAt(test.Hour, test.Minute); err == nil {
+			t.Errorf("expected an error for %d:%d", test.Hour, test.Minute)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package cronschedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetNow replaces the clock used by the package and returns a function restoring the original.
+func SetNow(f func() time.Time) func() {
+	original := now
+	now = f
+	return func() {
+		now = original
+	}
+}
+
+// PrettyStringConcat is the previous implementation of PrettyString built by concatenation. It is kept to compare
+// against PrettyString in benchmarks and tests.
+func (s *Schedule) PrettyStringConcat() string {
+	prettyString := ""
+	prettyString += fmt.Sprintf("Cron Schedule:     [%s]\n", s.ScheduleStr)
+	prettyString += fmt.Sprintf("Minute:            %s => [%#v]\n", s.MinutesStr, sortMapKeys(s.Minutes))
+	prettyString += fmt.Sprintf("Hour:              %s => [%#v]\n", s.HoursStr, sortMapKeys(s.Hours))
+	prettyString += fmt.Sprintf("Days Of The Month: %s => [%s]\n", s.DaysOfMonthStr, s.prettyDayValues(2))
+	prettyString += fmt.Sprintf("Month:             %s => [%#v]\n", s.MonthsStr, sortMapKeys(s.Months))
+	prettyString += fmt.Sprintf("Day Of The Week:   %s => [%s]\n", s.DaysOfTheWeekStr, s.prettyDayValues(4))
+	return prettyString
+}
+
+// CanonicalString generates the result of String without using the cache.
+func (s *Schedule) CanonicalString() string {
+	return s.canonicalString()
+}
+
+// StringCached returns true if String provides the cached result rather than generating it.
+func (s *Schedule) StringCached() bool {
+	return s.canonical != "" && s.canonicalLocation == s.Location
+}
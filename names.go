@@ -0,0 +1,45 @@
+package cronschedule
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// nameRe matches the names that may be used in place of numbers in the month and day of week fields.
+var nameRe = regexp.MustCompile(`[A-Za-z]+`)
+
+// monthNames maps the three letter month names to their month numbers.
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+// dayNames maps the three letter day of week names to their POSIX day of week numbers.
+var dayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// translateNames replaces the month or day of week names within the value of the field at index with the numbers they
+// represent. Names are matched case insensitively and day of week names are numbered using _convention_. Words that
+// are not names, and names in any other field, are left unchanged to be rejected by the parser.
+func translateNames(value string, index int, convention DayOfWeekConvention) string {
+	if index != 3 && index != 4 {
+		return value
+	}
+
+	return nameRe.ReplaceAllStringFunc(value, func(name string) string {
+		upper := strings.ToUpper(name)
+		if index == 3 {
+			if month, ok := monthNames[upper]; ok {
+				return strconv.Itoa(month)
+			}
+			return name
+		}
+
+		if day, ok := dayNames[upper]; ok {
+			return strconv.Itoa(convention.fromPOSIX(day))
+		}
+		return name
+	})
+}
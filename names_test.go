@@ -0,0 +1,73 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+)
+
+func TestParseNames(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected string
+	}{
+		// The name translated start of an interval. Every other month from January is the same as */2.
+		{"0 0 1 JAN/2 *", "0 0 1 */2 *"},
+		{"0 0 1 feb/3 *", "0 0 1 2,5,8,11 *"},
+		{"0 0 1 JAN-MAR,Jul *", "0 0 1 1-3,7 *"},
+		{"0 0 1 JAN-DEC/6 *", "0 0 1 1,7 *"},
+		{"0 9 * * MON-FRI", "0 9 * * 1-5"},
+		{"0 9 * * sun,SAT", "0 9 * * 0,6"},
+		{"0 9 * * FRI#1", "0 9 * * 5#1"},
+		{"0 9 * * WED", "0 9 * * 3"},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if str := schedule.String(); str != test.Expected {
+			t.Errorf("expected %s to parse as %s but received %s", test.Schedule, test.Expected, str)
+		}
+	}
+
+	schedule, err := cronschedule.Parse("0 0 1 JAN/2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	expected := []int{1, 3, 5, 7, 9, 11}
+	months := schedule.FieldValues(3)
+	if len(months) != len(expected) {
+		t.Fatalf("expected months %v but received %v", expected, months)
+	}
+	for i := range expected {
+		if months[i] != expected[i] {
+			t.Errorf("expected months %v but received %v", expected, months)
+			break
+		}
+	}
+	if schedule.MonthsStr[0] != "JAN/2" {
+		t.Errorf("expected the month string to be kept as written but received %v", schedule.MonthsStr)
+	}
+
+	// Day names follow the day of week convention.
+	for _, convention := range []cronschedule.DayOfWeekConvention{cronschedule.DayOfWeekPOSIX, cronschedule.DayOfWeekQuartz, cronschedule.DayOfWeekISO} {
+		schedule, err := cronschedule.ParseWithOptions("0 9 * * SUN,MON-FRI", cronschedule.ParseOptions{DayOfWeek: convention})
+		if err != nil {
+			t.Errorf("%d|failed to parse day names: %s", convention, err)
+			continue
+		}
+
+		if str := schedule.String(); str != "0 9 * * 0-5" {
+			t.Errorf("%d|expected day names to parse as 0 9 * * 0-5 but received %s", convention, str)
+		}
+	}
+
+	for _, invalid := range []string{"0 0 1 FOO *", "0 0 1 * JAN", "0 0 1 MON *", "JAN 0 1 * *", "0 0 1 JANUARY *"} {
+		if _, err := cronschedule.Parse(invalid); err == nil {
+			t.Errorf("expected error parsing %s", invalid)
+		}
+	}
+}
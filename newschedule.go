@@ -0,0 +1,37 @@
+package cronschedule
+
+import "time"
+
+// Option configures a schedule created by NewSchedule.
+type Option func(s *Schedule)
+
+// WithClock configures the schedule to use _clock_ as the current time for ShouldExecuteNow and ShouldExecuteNowIn
+// rather than time.Now. This allows schedules to be tested without depending on the actual time.
+func WithClock(clock func() time.Time) Option {
+	return func(s *Schedule) {
+		s.clock = clock
+	}
+}
+
+// WithLocation configures the schedule to be evaluated in _loc_ as if the schedule started with a CRON_TZ token. It
+// replaces any location specified by a CRON_TZ token of the expression.
+func WithLocation(loc *time.Location) Option {
+	return func(s *Schedule) {
+		s.Location = loc
+		s.cacheString()
+	}
+}
+
+// NewSchedule parses the cron schedule _expr_ the same as Parse and then applies each option in order. Parse remains
+// the simplest way to create a schedule when no options are needed.
+func NewSchedule(expr string, opts ...Option) (Schedule, error) {
+	schedule, err := Parse(expr)
+	if err != nil {
+		return schedule, err
+	}
+
+	for _, opt := range opts {
+		opt(&schedule)
+	}
+	return schedule, nil
+}
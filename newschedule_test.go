@@ -0,0 +1,63 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+	"time"
+)
+
+func TestNewScheduleWithClock(t *testing.T) {
+	current := time.Date(2020, time.July, 23, 10, 30, 0, 0, time.UTC)
+	clock := func() time.Time {
+		return current
+	}
+
+	schedule, err := cronschedule.NewSchedule("30 10 * * *", cronschedule.WithClock(clock), cronschedule.WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if !schedule.ShouldExecuteNow() {
+		t.Errorf("expected schedule to execute at the fake clock time %v", current)
+	}
+
+	current = current.Add(time.Minute)
+	if schedule.ShouldExecuteNow() {
+		t.Errorf("expected schedule not to execute at the fake clock time %v", current)
+	}
+
+	// The location evaluates the fake clock time regardless of the location requested.
+	current = time.Date(2020, time.July, 23, 10, 30, 0, 0, time.UTC)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %s", err)
+	}
+	if !schedule.ShouldExecuteNowIn(tokyo) {
+		t.Errorf("expected schedule with a location to execute at %v regardless of the location requested", current)
+	}
+}
+
+func TestNewScheduleWithLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %s", err)
+	}
+
+	schedule, err := cronschedule.NewSchedule("CRON_TZ=UTC 0 9 * * *", cronschedule.WithLocation(tokyo))
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	if schedule.Location != tokyo {
+		t.Errorf("expected the option to replace the CRON_TZ location but received %v", schedule.Location)
+	}
+
+	expected := time.Date(2020, time.July, 24, 9, 0, 0, 0, tokyo)
+	if next := schedule.NextExecution(time.Date(2020, time.July, 23, 12, 0, 0, 0, tokyo)); !next.Equal(expected) {
+		t.Errorf("expected next execution %v but received %v", expected, next)
+	}
+
+	if _, err := cronschedule.NewSchedule("0 9 * *", cronschedule.WithLocation(tokyo)); err == nil {
+		t.Errorf("expected error for an invalid expression")
+	}
+}
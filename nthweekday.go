@@ -0,0 +1,124 @@
+package cronschedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldNthWeekdayMin and FieldNthWeekdayMax are the occurrences of a day of the week within a month allowed by the #
+// operator.
+const FieldNthWeekdayMin int = 1
+const FieldNthWeekdayMax int = 5
+
+// NthWeekday is an occurrence of a day of the week within a month as specified by the # operator of the day of week
+// field, e.g. 5#1 for the first Friday of the month.
+//
+// Occurrences are counted from the first of the month rather than by calendar week: 5#1 is the first Friday of the
+// month even when it falls on the 7th, and is never a Friday of the previous month's last week. The Nth occurrence of a
+// day of the week therefore always falls on days 7(N-1)+1 through 7N of the month. The 5th occurrence only exists in
+// months where that day of the week occurs five times, so 5#5 does not execute in every month.
+type NthWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// String returns the NthWeekday in the # operator form using the POSIX day of week numbering.
+func (n NthWeekday) String() string {
+	return fmt.Sprintf("%d#%d", n.Weekday, n.N)
+}
+
+// matches returns true if the date _t_ is the Nth occurrence of the day of the week within its month.
+func (n NthWeekday) matches(t time.Time) bool {
+	return t.Weekday() == n.Weekday && (t.Day()-1)/7+1 == n.N
+}
+
+// parseNthWeekday parses a day of week value using the # operator such as 5#1. The day of the week is validated against
+// the _min_ and _max_ of the field and converted to the POSIX numbering using _convention_.
+func parseNthWeekday(value string, min int, max int, convention DayOfWeekConvention) (NthWeekday, error) {
+	tokens := strings.Split(value, "#")
+	if len(tokens) != 2 {
+		return NthWeekday{}, fmt.Errorf("expected a single # operator")
+	}
+
+	day, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return NthWeekday{}, fmt.Errorf("failed to parse day of the week %s: %s", tokens[0], err)
+	}
+	if day < min || day > max {
+		return NthWeekday{}, fmt.Errorf("day of the week %d is not between %d and %d", day, min, max)
+	}
+
+	n, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return NthWeekday{}, fmt.Errorf("failed to parse occurrence %s: %s", tokens[1], err)
+	}
+	if n < FieldNthWeekdayMin || n > FieldNthWeekdayMax {
+		return NthWeekday{}, fmt.Errorf("occurrence %d is not between %d and %d", n, FieldNthWeekdayMin, FieldNthWeekdayMax)
+	}
+
+	return NthWeekday{Weekday: time.Weekday(convention.toPOSIX(day)), N: n}, nil
+}
+
+// AddNthDaysOfTheWeek adds the occurrences of days of the week listed to the schedule. Duplicates and invalid values
+// are ignored.
+func (s *Schedule) AddNthDaysOfTheWeek(nthWeekdays []NthWeekday) {
+	s.canonical = ""
+	for _, nth := range nthWeekdays {
+		if nth.Weekday < time.Sunday || nth.Weekday > time.Saturday || nth.N < FieldNthWeekdayMin || nth.N > FieldNthWeekdayMax {
+			continue
+		}
+		if s.containsNthWeekday(nth) {
+			continue
+		}
+		s.NthDaysOfTheWeek = append(s.NthDaysOfTheWeek, nth)
+	}
+
+	sort.Slice(s.NthDaysOfTheWeek, func(i, j int) bool {
+		if s.NthDaysOfTheWeek[i].Weekday != s.NthDaysOfTheWeek[j].Weekday {
+			return s.NthDaysOfTheWeek[i].Weekday < s.NthDaysOfTheWeek[j].Weekday
+		}
+		return s.NthDaysOfTheWeek[i].N < s.NthDaysOfTheWeek[j].N
+	})
+}
+
+// containsNthWeekday returns true if the schedule contains the occurrence _nth_.
+func (s *Schedule) containsNthWeekday(nth NthWeekday) bool {
+	for _, existing := range s.NthDaysOfTheWeek {
+		if existing == nth {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDayOfWeek returns true if the day of week field contains any day of the week or occurrence of one.
+func (s *Schedule) hasDayOfWeek() bool {
+	return len(s.DaysOfTheWeek) != 0 || len(s.NthDaysOfTheWeek) != 0
+}
+
+// dayOfWeekMatches returns true if the day of week field matches the date _t_ either by the day of the week or by an
+// occurrence of it within the month.
+func (s *Schedule) dayOfWeekMatches(t time.Time) bool {
+	if _, ok := s.DaysOfTheWeek[int(t.Weekday())]; ok {
+		return true
+	}
+
+	for _, nth := range s.NthDaysOfTheWeek {
+		if nth.matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// nthWeekdayStrings returns the occurrences of the schedule in the # operator form.
+func (s *Schedule) nthWeekdayStrings() []string {
+	strs := make([]string, len(s.NthDaysOfTheWeek))
+	for i, nth := range s.NthDaysOfTheWeek {
+		strs[i] = nth.String()
+	}
+	return strs
+}
@@ -0,0 +1,158 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNthWeekdayOccurrence(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Start    time.Time
+		Expected []time.Time
+	}{
+		// The first Friday is counted from the first of the month even when it is the 7th and the month started in
+		// the middle of a week.
+		{"CRON_TZ=UTC 0 9 * * 5#1", time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2020, time.July, 3, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.August, 7, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.September, 4, 9, 0, 0, 0, time.UTC),
+		}},
+		// The second Monday.
+		{"CRON_TZ=UTC 0 9 * * 1#2", time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2020, time.June, 8, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.July, 13, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.August, 10, 9, 0, 0, 0, time.UTC),
+		}},
+		// The fifth Friday only occurs in months with five Fridays.
+		{"CRON_TZ=UTC 0 9 * * 5#5", time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2020, time.July, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.October, 30, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, time.January, 29, 9, 0, 0, 0, time.UTC),
+		}},
+		// Occurrences are ORed with the days of the week and the day of month.
+		{"CRON_TZ=UTC 0 9 15 * 0,5#1", time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2020, time.July, 3, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.July, 5, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.July, 12, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, time.July, 15, 9, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		execTimes := schedule.NextExecutions(test.Start, len(test.Expected))
+		for i := range test.Expected {
+			if i >= len(execTimes) || !execTimes[i].Equal(test.Expected[i]) {
+				t.Errorf("%s|expected executions %v but received %v", test.Schedule, test.Expected, execTimes)
+				break
+			}
+
+			if !schedule.ShouldExecute(test.Expected[i]) {
+				t.Errorf("%s|expected schedule to execute at %v", test.Schedule, test.Expected[i])
+			}
+		}
+
+		previous := schedule.PreviousExecution(test.Expected[len(test.Expected)-1])
+		if !previous.Equal(test.Expected[len(test.Expected)-2]) {
+			t.Errorf("%s|expected previous execution %v but received %v", test.Schedule, test.Expected[len(test.Expected)-2], previous)
+		}
+	}
+}
+
+func TestNthWeekdayNotWeekOfMonth(t *testing.T) {
+	schedule, err := cronschedule.Parse("CRON_TZ=UTC 0 9 * * 5#1")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	// May 2020 started on a Friday so the Friday of the second calendar week is the second Friday.
+	if schedule.ShouldExecute(time.Date(2020, time.May, 8, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the second Friday not to match 5#1")
+	}
+	if !schedule.ShouldExecute(time.Date(2020, time.May, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the first Friday to match 5#1")
+	}
+}
+
+func TestParseNthWeekday(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected string
+	}{
+		{"0 9 * * 5#1", "0 9 * * 5#1"},
+		{"0 9 * * 7#2", "0 9 * * 0#2"},
+		{"0 9 * * 5#1,1-3,5#1", "0 9 * * 1-3,5#1"},
+		{"0 9 1 * 0#3", "0 9 1 * 0#3"},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		if str := schedule.String(); str != test.Expected {
+			t.Errorf("expected %s to be written as %s but received %s", test.Schedule, test.Expected, str)
+		}
+
+		roundTrip, err := cronschedule.Parse(schedule.String())
+		if err != nil || !schedule.Equal(&roundTrip) {
+			t.Errorf("expected %s to round trip but received %v", test.Schedule, err)
+		}
+	}
+
+	// The occurrence is counted from the first of the month regardless of the day of week convention.
+	schedule, err := cronschedule.ParseWithOptions("0 9 * * 5#1", cronschedule.ParseOptions{DayOfWeek: cronschedule.DayOfWeekISO})
+	if err != nil {
+		t.Fatalf("failed to parse ISO occurrence: %s", err)
+	}
+	if len(schedule.NthDaysOfTheWeek) != 1 || schedule.NthDaysOfTheWeek[0] != (cronschedule.NthWeekday{Weekday: time.Friday, N: 1}) {
+		t.Errorf("expected ISO 5#1 to be the first Friday but received %v", schedule.NthDaysOfTheWeek)
+	}
+
+	for _, invalid := range []string{"0 9 * * 5#0", "0 9 * * 5#6", "0 9 * * 8#1", "0 9 * * 5#", "0 9 * * #1", "0 9 * * 5#1#2", "0 9 5#1 * *"} {
+		if _, err := cronschedule.Parse(invalid); err == nil {
+			t.Errorf("expected error parsing %s", invalid)
+		}
+	}
+}
+
+func TestParseSpecialTokenCombinations(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected string
+	}{
+		{"0 0 1,L * *", "L cannot be combined with other values in a list"},
+		{"0 0 * * 15W#3", "W cannot be combined with #"},
+		{"0 0 * * 5L#2", "L cannot be combined with #"},
+		{"0 0 1,15W * *", "W cannot be combined with other values in a list"},
+		{"0 0 L * *", "L is not supported"},
+		{"0 0 15w * *", "W is not supported"},
+		{"0 0 ? * 1", "? is not supported"},
+		{"0 0 5#1 * *", "# is only allowed in the day of week field"},
+		{"0 0 * * 5#1#2", "only one # is allowed"},
+		{"0 0 * * 1-5#2", "# must follow a single day of the week"},
+		{"0 0 * * */2#1", "# must follow a single day of the week"},
+	}
+
+	for _, test := range tests {
+		_, err := cronschedule.Parse(test.Schedule)
+		if err == nil {
+			t.Errorf("expected error parsing %s", test.Schedule)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), test.Expected) {
+			t.Errorf("expected error for %s to contain [%s] but received [%s]", test.Schedule, test.Expected, err)
+		}
+	}
+}
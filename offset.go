@@ -0,0 +1,26 @@
+package cronschedule
+
+import "time"
+
+// Offset returns a copy of the schedule with every execution time shifted by _d_. This allows staggering correlated
+// jobs, including by sub-minute amounts, without rewriting the cron schedule. The copy may be used anywhere a Schedule
+// is accepted as ShouldExecute, NextExecutions, PreviousExecutions, and the methods built on them all use the shifted
+// times. Offsetting a schedule that already has an offset shifts it further. The offset cannot be written in a cron
+// schedule so it is not included by String.
+func (s *Schedule) Offset(d time.Duration) *Schedule {
+	offset := s.clone()
+	offset.offset = s.offset + d
+	return offset
+}
+
+// shiftTimes shifts each of the _times_ generated without the offset by the offset of the schedule.
+func (s *Schedule) shiftTimes(times []time.Time) []time.Time {
+	if s.offset == 0 {
+		return times
+	}
+
+	for i := range times {
+		times[i] = times[i].Add(s.offset)
+	}
+	return times
+}
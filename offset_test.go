@@ -0,0 +1,104 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+	"time"
+)
+
+func TestOffsetNextExecutions(t *testing.T) {
+	offsets := []time.Duration{30 * time.Second, 5 * time.Minute, -90 * time.Second}
+
+	for _, param := range CronTestData {
+		schedule, err := cronschedule.Parse(param.Schedule)
+		if err != nil {
+			t.Errorf("%d|failed to build schedule for %s: %s", param.ID, param.Schedule, err)
+			continue
+		}
+
+		baseTimes := schedule.NextExecutions(param.T, 5)
+
+		for _, d := range offsets {
+			offsetTimes := schedule.Offset(d).NextExecutions(param.T.Add(d), 5)
+			if len(offsetTimes) != len(baseTimes) {
+				t.Errorf("%d|expected %d times with offset %v but received %d", param.ID, len(baseTimes), d, len(offsetTimes))
+				continue
+			}
+
+			for i := range baseTimes {
+				if offsetTimes[i].Sub(baseTimes[i]) != d {
+					t.Errorf("%d|expected %v to be shifted by %v but received %v", param.ID, baseTimes[i], d, offsetTimes[i])
+				}
+			}
+		}
+	}
+}
+
+func TestOffsetShouldExecute(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 22 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	offset := schedule.Offset(30 * time.Second)
+	shifted := time.Date(2020, time.July, 23, 22, 0, 30, 0, time.Local)
+	if !offset.ShouldExecute(shifted) {
+		t.Errorf("expected offset schedule to execute at %v", shifted)
+	}
+
+	if next := offset.NextExecution(time.Date(2020, time.July, 23, 22, 0, 0, 0, time.Local)); !next.Equal(shifted) {
+		t.Errorf("expected next execution of %v but received %v", shifted, next)
+	}
+}
+
+func TestOffsetPreviousExecutions(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/15 9-17 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	start := time.Date(2020, time.July, 27, 9, 10, 0, 0, time.Local)
+	baseTimes := schedule.PreviousExecutions(start, 5)
+
+	d := 45 * time.Second
+	offset := schedule.Offset(d)
+	offsetTimes := offset.PreviousExecutions(start.Add(d), 5)
+	if len(offsetTimes) != len(baseTimes) {
+		t.Fatalf("expected %d times but received %d", len(baseTimes), len(offsetTimes))
+	}
+	for i := range baseTimes {
+		if offsetTimes[i].Sub(baseTimes[i]) != d {
+			t.Errorf("expected %v to be shifted by %v but received %v", baseTimes[i], d, offsetTimes[i])
+		}
+	}
+
+	// Offsets accumulate while the original schedule is left unshifted.
+	if next := offset.Offset(d).NextExecution(start); !next.Equal(schedule.NextExecution(start).Add(2 * d)) {
+		t.Errorf("expected next execution shifted by %v but received %v", 2*d, next)
+	}
+	if next := schedule.NextExecution(start); next.Second() != 0 {
+		t.Errorf("expected the original schedule to be unshifted but received %v", next)
+	}
+	if offset.Equal(&schedule) {
+		t.Errorf("expected the offset schedule not to equal the original")
+	}
+}
+
+func TestOffsetUnsatisfiable(t *testing.T) {
+	schedule, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	offset := schedule.Offset(30 * time.Second)
+	from := time.Date(2020, time.July, 23, 10, 0, 0, 0, time.Local)
+	if next := offset.Next(from); !next.IsZero() {
+		t.Errorf("expected zero time for a schedule that never executes but received %v", next)
+	}
+	if prev := offset.Prev(from); !prev.IsZero() {
+		t.Errorf("expected zero time for a schedule that never executes but received %v", prev)
+	}
+	if execTimes := offset.TimesInNextDuration(from, 24*time.Hour); len(execTimes) != 0 {
+		t.Errorf("expected no executions for a schedule that never executes but received %v", execTimes)
+	}
+}
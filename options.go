@@ -0,0 +1,111 @@
+package cronschedule
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// DayOfWeekConvention determines how the numerical values of the day of week field are interpreted.
+type DayOfWeekConvention int
+
+const (
+	// DayOfWeekPOSIX numbers the days of the week 0-6 starting with Sunday. Sunday may also be written as 7 which is
+	// used as the end of the field when generating ranges and intervals.
+	DayOfWeekPOSIX DayOfWeekConvention = iota
+
+	// DayOfWeekQuartz numbers the days of the week 1-7 starting with Sunday.
+	DayOfWeekQuartz
+
+	// DayOfWeekISO numbers the days of the week 1-7 starting with Monday.
+	DayOfWeekISO
+)
+
+// minMax returns the minimum and maximum day of week values for the convention.
+func (c DayOfWeekConvention) minMax() (min int, max int) {
+	switch c {
+	case DayOfWeekQuartz, DayOfWeekISO:
+		return 1, 7
+	default:
+		return FieldDayOfTheWeekMin, FieldDayOfTheWeekMax + 1
+	}
+}
+
+// toPOSIX converts the day of week value from the convention to the POSIX numbering used internally.
+func (c DayOfWeekConvention) toPOSIX(value int) int {
+	switch c {
+	case DayOfWeekQuartz:
+		return value - 1
+	default:
+		return value % 7
+	}
+}
+
+// fromPOSIX converts the POSIX day of week value to the numbering of the convention.
+func (c DayOfWeekConvention) fromPOSIX(value int) int {
+	switch c {
+	case DayOfWeekQuartz:
+		return value + 1
+	case DayOfWeekISO:
+		if value == 0 {
+			return 7
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// ParseOptions configures the parsing performed by ParseWithOptions. The zero value parses the same as Parse.
+type ParseOptions struct {
+	// DayOfWeek is the numbering convention of the day of week field. Defaults to DayOfWeekPOSIX.
+	DayOfWeek DayOfWeekConvention
+
+	// Strict rejects values that are accepted by default but are almost certainly mistakes, such as a step larger
+	// than the maximum value of its field.
+	Strict bool
+
+	// NumericOnly restricts each field to either * or a single number. Steps, ranges, and lists are rejected which
+	// limits the schedules accepted from untrusted sources to simple ones.
+	NumericOnly bool
+
+	// FieldOrder maps the position of each field in the schedule to the index of the field as described by
+	// AddByIndex. For example an order of [4, 0, 1, 2, 3] parses schedules written with the day of week first. It must
+	// be a permutation of 0 through 4. Defaults to [0, 1, 2, 3, 4] when nil.
+	FieldOrder []int
+
+	// MaxListSegments is the maximum number of comma separated values allowed in a single field, protecting servers
+	// parsing untrusted schedules from pathological lists. Defaults to DefaultMaxListSegments when zero or less.
+	MaxListSegments int
+
+	// Random chooses the value of each a~b value, a random value from a through b inclusive chosen once when the
+	// schedule is parsed. Values using ~ are rejected when nil. See ParseRandomized.
+	Random *rand.Rand
+}
+
+// DefaultMaxListSegments is the maximum number of comma separated values allowed in a single field when
+// ParseOptions.MaxListSegments is not set.
+const DefaultMaxListSegments = 1000
+
+// maxListSegments returns the maximum number of comma separated values allowed in a single field.
+func (o ParseOptions) maxListSegments() int {
+	if o.MaxListSegments <= 0 {
+		return DefaultMaxListSegments
+	}
+	return o.MaxListSegments
+}
+
+// validateFieldOrder returns an error if _order_ is not a permutation of the field indexes.
+func validateFieldOrder(order []int) error {
+	if len(order) != 5 {
+		return fmt.Errorf("field order %v must contain 5 field indexes", order)
+	}
+
+	var seen [5]bool
+	for _, index := range order {
+		if index < 0 || index > 4 || seen[index] {
+			return fmt.Errorf("field order %v must be a permutation of 0 through 4", order)
+		}
+		seen[index] = true
+	}
+	return nil
+}
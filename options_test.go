@@ -0,0 +1,179 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"strings"
+	"testing"
+)
+
+func TestParseDayOfWeekConvention(t *testing.T) {
+	tests := []struct {
+		Convention cronschedule.DayOfWeekConvention
+		Sunday     string
+		Invalid    string
+	}{
+		{cronschedule.DayOfWeekPOSIX, "0", "8"},
+		{cronschedule.DayOfWeekQuartz, "1", "0"},
+		{cronschedule.DayOfWeekISO, "7", "0"},
+	}
+
+	for _, test := range tests {
+		opts := cronschedule.ParseOptions{DayOfWeek: test.Convention}
+
+		schedule, err := cronschedule.ParseWithOptions("0 0 * * "+test.Sunday, opts)
+		if err != nil {
+			t.Errorf("%d|failed to parse Sunday as %s: %s", test.Convention, test.Sunday, err)
+			continue
+		}
+
+		if len(schedule.DaysOfWeekSlice) != 1 || schedule.DaysOfWeekSlice[0] != 0 {
+			t.Errorf("%d|expected %s to be parsed as Sunday but received %v", test.Convention, test.Sunday, schedule.DaysOfWeekSlice)
+		}
+
+		if _, err := cronschedule.ParseWithOptions("0 0 * * "+test.Invalid, opts); err == nil {
+			t.Errorf("%d|expected error parsing day of week %s", test.Convention, test.Invalid)
+		}
+	}
+
+	// Monday through Friday in ISO numbering.
+	schedule, err := cronschedule.ParseWithOptions("0 0 * * 1-5", cronschedule.ParseOptions{DayOfWeek: cronschedule.DayOfWeekISO})
+	if err != nil {
+		t.Fatalf("failed to parse ISO weekdays: %s", err)
+	}
+	if str := schedule.String(); str != "0 0 * * 1-5" {
+		t.Errorf("expected ISO weekdays to be 0 0 * * 1-5 but received %s", str)
+	}
+}
+
+func TestParseStrictStep(t *testing.T) {
+	lenient, err := cronschedule.Parse("*/1000 * * * *")
+	if err != nil {
+		t.Fatalf("expected lenient parse to succeed: %s", err)
+	}
+	if minutes := lenient.FieldValues(0); len(minutes) != 1 || minutes[0] != 0 {
+		t.Errorf("expected lenient minutes [0] but received %v", minutes)
+	}
+
+	strict := cronschedule.ParseOptions{Strict: true}
+	_, err = cronschedule.ParseWithOptions("*/1000 * * * *", strict)
+	if err == nil {
+		t.Fatalf("expected strict parse to fail")
+	}
+	if !strings.Contains(err.Error(), "step 1000 exceeds field maximum 59") {
+		t.Errorf("expected step error but received %s", err)
+	}
+
+	for _, valid := range []string{"*/59 * * * *", "0 0-23/23 * * *", "0 0 1/31 * *"} {
+		if _, err := cronschedule.ParseWithOptions(valid, strict); err != nil {
+			t.Errorf("expected strict parse of %s to succeed: %s", valid, err)
+		}
+	}
+}
+
+func TestParseDayOfWeekSeven(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Expected []int
+	}{
+		{"0 0 * * 7", []int{0}},
+		{"0 0 * * 5-7", []int{0, 5, 6}},
+		{"0 0 * * 0-7", []int{0, 1, 2, 3, 4, 5, 6}},
+		{"0 0 * * 0-7/2", []int{0, 2, 4, 6}},
+		{"0 0 * * 7/1", []int{0}},
+		{"0 0 * * 0,7", []int{0}},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		days := schedule.FieldValues(4)
+		if len(days) != len(test.Expected) {
+			t.Errorf("expected days of week %v for %s but received %v", test.Expected, test.Schedule, days)
+			continue
+		}
+
+		for i := range test.Expected {
+			if days[i] != test.Expected[i] {
+				t.Errorf("expected days of week %v for %s but received %v", test.Expected, test.Schedule, days)
+				break
+			}
+		}
+	}
+}
+
+func TestParseNumericOnly(t *testing.T) {
+	opts := cronschedule.ParseOptions{NumericOnly: true}
+
+	for _, valid := range []string{"5 * * * *", "* * * * *", "0 22 1 8 5"} {
+		if _, err := cronschedule.ParseWithOptions(valid, opts); err != nil {
+			t.Errorf("expected numeric only parse of %s to succeed: %s", valid, err)
+		}
+	}
+
+	for _, invalid := range []string{"*/5 * * * *", "1-3 * * * *", "1,2 * * * *", "0 0 * * 1/2"} {
+		if _, err := cronschedule.ParseWithOptions(invalid, opts); err == nil {
+			t.Errorf("expected numeric only parse of %s to fail", invalid)
+		}
+
+		if _, err := cronschedule.Parse(invalid); err != nil {
+			t.Errorf("expected default parse of %s to succeed: %s", invalid, err)
+		}
+	}
+}
+
+func TestParseFieldOrder(t *testing.T) {
+	// Day of week written first followed by the standard order.
+	opts := cronschedule.ParseOptions{FieldOrder: []int{4, 0, 1, 2, 3}}
+	schedule, err := cronschedule.ParseWithOptions("1-5 30 9 * *", opts)
+	if err != nil {
+		t.Fatalf("failed to parse reordered schedule: %s", err)
+	}
+
+	if str := schedule.String(); str != "30 9 * * 1-5" {
+		t.Errorf("expected reordered schedule to be 30 9 * * 1-5 but received %s", str)
+	}
+
+	// Field errors name the field rather than the position.
+	if _, err := cronschedule.ParseWithOptions("8 30 9 * *", opts); err == nil || !strings.Contains(err.Error(), "day of week") {
+		t.Errorf("expected day of week error but received %v", err)
+	}
+
+	// Nicknames are always written in the standard order.
+	schedule, err = cronschedule.ParseWithOptions("@daily", opts)
+	if err != nil {
+		t.Fatalf("failed to parse nickname: %s", err)
+	}
+	if str := schedule.String(); str != "0 0 * * *" {
+		t.Errorf("expected nickname to be 0 0 * * * but received %s", str)
+	}
+
+	for _, order := range [][]int{{0, 1, 2, 3}, {0, 1, 2, 3, 3}, {0, 1, 2, 3, 5}, {0, 1, 2, 3, 4, 4}} {
+		if _, err := cronschedule.ParseWithOptions("* * * * *", cronschedule.ParseOptions{FieldOrder: order}); err == nil {
+			t.Errorf("expected error for field order %v", order)
+		}
+	}
+}
+
+func TestParseMaxListSegments(t *testing.T) {
+	oversized := strings.Repeat("0,", cronschedule.DefaultMaxListSegments) + "0"
+	if _, err := cronschedule.Parse(oversized + " * * * *"); err == nil || !strings.Contains(err.Error(), "exceeds the maximum") {
+		t.Errorf("expected error parsing %d minute values but received %v", cronschedule.DefaultMaxListSegments+1, err)
+	}
+
+	atLimit := strings.Repeat("0,", cronschedule.DefaultMaxListSegments-1) + "0"
+	if _, err := cronschedule.Parse(atLimit + " * * * *"); err != nil {
+		t.Errorf("expected %d minute values to parse but received %s", cronschedule.DefaultMaxListSegments, err)
+	}
+
+	opts := cronschedule.ParseOptions{MaxListSegments: 3}
+	if _, err := cronschedule.ParseWithOptions("0,15,30 * * * *", opts); err != nil {
+		t.Errorf("expected 3 minute values to parse but received %s", err)
+	}
+	if _, err := cronschedule.ParseWithOptions("0 * * * 1,2,3,4", opts); err == nil {
+		t.Errorf("expected error parsing 4 day of week values with a maximum of 3")
+	}
+}
@@ -0,0 +1,93 @@
+package cronschedule_test
+
+import (
+	"fmt"
+	"github.com/jrmycanady/cronschedule"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// oracleExecutions finds up to count execution times after t by checking every minute up to end with ShouldExecute.
+func oracleExecutions(s *cronschedule.Schedule, t time.Time, end time.Time, count int) []time.Time {
+	execTimes := make([]time.Time, 0, count)
+	for m := t.Truncate(time.Minute).Add(time.Minute); !m.After(end) && len(execTimes) < count; m = m.Add(time.Minute) {
+		if s.ShouldExecute(m) {
+			execTimes = append(execTimes, m)
+		}
+	}
+	return execTimes
+}
+
+// randomField generates a random value for a field with the min and max provided.
+func randomField(r *rand.Rand, min int, max int) string {
+	switch r.Intn(6) {
+	case 0:
+		return "*"
+	case 1:
+		return fmt.Sprintf("*/%d", r.Intn(max-min)+1)
+	case 2:
+		start := r.Intn(max-min+1) + min
+		end := r.Intn(max-start+1) + start
+		return fmt.Sprintf("%d-%d", start, end)
+	case 3:
+		start := r.Intn(max-min+1) + min
+		end := r.Intn(max-start+1) + start
+		return fmt.Sprintf("%d-%d/%d", start, end, r.Intn(max-min)+1)
+	case 4:
+		return fmt.Sprintf("%d,%d", r.Intn(max-min+1)+min, r.Intn(max-min+1)+min)
+	default:
+		return fmt.Sprintf("%d", r.Intn(max-min+1)+min)
+	}
+}
+
+func TestNextExecutionsOracle(t *testing.T) {
+	type oracleCase struct {
+		Schedule string
+		T        time.Time
+	}
+
+	cases := make([]oracleCase, 0)
+	for _, param := range CronTestData {
+		t := param.T
+		cases = append(cases, oracleCase{param.Schedule, time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)})
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		expr := fmt.Sprintf("%s %s %s %s %s",
+			randomField(r, 0, 59), randomField(r, 0, 23), randomField(r, 1, 31), randomField(r, 1, 12), randomField(r, 0, 6))
+		start := time.Date(2019+r.Intn(3), time.Month(r.Intn(12)+1), r.Intn(28)+1, r.Intn(24), r.Intn(60), r.Intn(60), 0, time.UTC)
+		cases = append(cases, oracleCase{expr, start})
+	}
+
+	for _, c := range cases {
+		// Evaluating in UTC so daylight saving transitions of the local location, where wall clock minutes repeat or
+		// are skipped, do not affect the comparison.
+		schedule, err := cronschedule.Parse("CRON_TZ=UTC " + c.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", c.Schedule, err)
+			continue
+		}
+
+		end := c.T.AddDate(1, 0, 0)
+		expected := oracleExecutions(&schedule, c.T, end, 5)
+		if len(expected) == 0 {
+			// Random day of month and month combinations may never execute in which case generation would not end.
+			continue
+		}
+		execTimes := schedule.NextExecutions(c.T, 5)
+
+		for i := range expected {
+			if i >= len(execTimes) || !execTimes[i].Equal(expected[i]) {
+				t.Errorf("%s from %v|expected %v but received %v", c.Schedule, c.T, expected, execTimes)
+				break
+			}
+		}
+
+		// Any generated time not found by the oracle must be beyond the searched window.
+		if len(expected) < len(execTimes) && !execTimes[len(expected)].After(end) {
+			t.Errorf("%s from %v|generated %v within the window but the oracle found only %v", c.Schedule, c.T, execTimes, expected)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cronschedule
+
+// FieldKind is the kind of value used to specify a field.
+type FieldKind int
+
+const (
+	// FieldKindWildcard is a field specified as *.
+	FieldKindWildcard FieldKind = iota
+
+	// FieldKindSingle is a field specified as a single number.
+	FieldKindSingle
+
+	// FieldKindRange is a field specified as a range of numbers such as 1-5.
+	FieldKindRange
+
+	// FieldKindStep is a field specified with an interval such as */5, 1-20/5, or 5/10.
+	FieldKindStep
+
+	// FieldKindList is a field specified as multiple comma separated values.
+	FieldKindList
+)
+
+// String returns the name of the kind.
+func (k FieldKind) String() string {
+	switch k {
+	case FieldKindWildcard:
+		return "wildcard"
+	case FieldKindSingle:
+		return "single"
+	case FieldKindRange:
+		return "range"
+	case FieldKindStep:
+		return "step"
+	case FieldKindList:
+		return "list"
+	default:
+		return "unknown"
+	}
+}
+
+// FieldInfo describes how a single field of a schedule was specified.
+type FieldInfo struct {
+	// Kind is the kind of value used to specify the field.
+	Kind FieldKind
+
+	// Count is the number of values resolved for the field. A day field cleared by Parse has a count of zero.
+	Count int
+}
+
+// ParseInfo describes how each field of a parsed schedule was specified. Fields are indexed by the cron schedule
+// format as described by AddByIndex.
+type ParseInfo struct {
+	Fields [5]FieldInfo
+}
+
+// ParseDetailed is the same as Parse but also provides details of how each field was specified, which is useful for
+// user interfaces and linters.
+func ParseDetailed(s string) (Schedule, ParseInfo, error) {
+	return parseDetailed(s, ParseOptions{})
+}
@@ -0,0 +1,36 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+)
+
+func TestParseDetailed(t *testing.T) {
+	_, info, err := cronschedule.ParseDetailed("*/5 1-3 15 1,2 1")
+	if err != nil {
+		t.Fatalf("failed to parse schedule: %s", err)
+	}
+
+	expected := [5]cronschedule.FieldInfo{
+		{Kind: cronschedule.FieldKindStep, Count: 12},
+		{Kind: cronschedule.FieldKindRange, Count: 3},
+		{Kind: cronschedule.FieldKindSingle, Count: 1},
+		{Kind: cronschedule.FieldKindList, Count: 2},
+		{Kind: cronschedule.FieldKindSingle, Count: 1},
+	}
+
+	for i := range expected {
+		if info.Fields[i] != expected[i] {
+			t.Errorf("expected field %d to be %s with %d values but received %s with %d values", i, expected[i].Kind, expected[i].Count, info.Fields[i].Kind, info.Fields[i].Count)
+		}
+	}
+
+	_, info, err = cronschedule.ParseDetailed("0 22 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to parse schedule: %s", err)
+	}
+
+	if info.Fields[2].Kind != cronschedule.FieldKindWildcard || info.Fields[2].Count != 0 {
+		t.Errorf("expected cleared day of month to be a wildcard with 0 values but received %s with %d values", info.Fields[2].Kind, info.Fields[2].Count)
+	}
+}
@@ -0,0 +1,55 @@
+package cronschedule
+
+import "time"
+
+// Plan is a set of schedules that together describe when a single job executes, such as a job running at 9:00 on
+// weekdays and at 12:00 on weekends which cannot be written as one cron schedule. The job executes whenever any of the
+// schedules does.
+type Plan struct {
+	Schedules []Schedule
+}
+
+// NewPlan creates a plan executing whenever any of _schedules_ does.
+func NewPlan(schedules ...Schedule) *Plan {
+	return &Plan{Schedules: schedules}
+}
+
+// ShouldExecute returns true if any schedule of the plan should be executed at time _t_.
+func (p *Plan) ShouldExecute(t time.Time) bool {
+	for i := range p.Schedules {
+		if p.Schedules[i].ShouldExecute(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matching returns the indexes of the schedules of the plan that should be executed at time _t_ in ascending order.
+// Schedules are matched on the minute of _t_ the same as ShouldExecute so several schedules may match the same minute.
+func (p *Plan) Matching(t time.Time) []int {
+	matching := make([]int, 0)
+	for i := range p.Schedules {
+		if p.Schedules[i].ShouldExecute(t) {
+			matching = append(matching, i)
+		}
+	}
+	return matching
+}
+
+// NextExecution returns the earliest next execution after _t_ across every schedule of the plan. Schedules that can
+// never execute are ignored and the zero time is returned if none of the schedules can execute. When several schedules
+// execute at the same minute that minute is returned once and Matching provides every schedule executing at it.
+func (p *Plan) NextExecution(t time.Time) time.Time {
+	var earliest time.Time
+	for i := range p.Schedules {
+		if !p.Schedules[i].IsSatisfiable() {
+			continue
+		}
+
+		next := p.Schedules[i].NextExecution(t)
+		if earliest.IsZero() || next.Before(earliest) {
+			earliest = next
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,105 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+	"time"
+)
+
+func TestPlan(t *testing.T) {
+	weekdays, err := cronschedule.Parse("0 9 * * 1-5")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	weekends, err := cronschedule.Parse("0 12 * * 0,6")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	plan := cronschedule.NewPlan(weekdays, weekends)
+
+	tests := []struct {
+		Start    time.Time
+		Expected time.Time
+	}{
+		// Thursday picks the weekday schedule.
+		{time.Date(2020, time.July, 23, 10, 0, 0, 0, time.Local), time.Date(2020, time.July, 24, 9, 0, 0, 0, time.Local)},
+		// Friday after 9:00 picks the Saturday execution of the weekend schedule.
+		{time.Date(2020, time.July, 24, 10, 0, 0, 0, time.Local), time.Date(2020, time.July, 25, 12, 0, 0, 0, time.Local)},
+		{time.Date(2020, time.July, 25, 12, 0, 0, 0, time.Local), time.Date(2020, time.July, 26, 12, 0, 0, 0, time.Local)},
+		// Sunday after 12:00 picks Monday.
+		{time.Date(2020, time.July, 26, 13, 0, 0, 0, time.Local), time.Date(2020, time.July, 27, 9, 0, 0, 0, time.Local)},
+	}
+
+	for _, test := range tests {
+		if next := plan.NextExecution(test.Start); !next.Equal(test.Expected) {
+			t.Errorf("expected next execution %v after %v but received %v", test.Expected, test.Start, next)
+		}
+
+		if !plan.ShouldExecute(test.Expected) {
+			t.Errorf("expected plan to execute at %v", test.Expected)
+		}
+	}
+
+	if plan.ShouldExecute(time.Date(2020, time.July, 25, 9, 0, 0, 0, time.Local)) {
+		t.Errorf("expected plan not to execute at 9:00 on Saturday")
+	}
+
+	if next := cronschedule.NewPlan().NextExecution(tests[0].Start); !next.IsZero() {
+		t.Errorf("expected zero time for an empty plan but received %v", next)
+	}
+}
+
+func TestPlanSharedMinute(t *testing.T) {
+	daily, err := cronschedule.Parse("0 9 * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	mondays, err := cronschedule.Parse("*/30 9 * * 1")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	plan := cronschedule.NewPlan(daily, mondays)
+
+	// Both schedules execute at 9:00 on Monday which is returned only once.
+	expected := []time.Time{
+		time.Date(2020, time.July, 27, 9, 0, 0, 0, time.Local),
+		time.Date(2020, time.July, 27, 9, 30, 0, 0, time.Local),
+		time.Date(2020, time.July, 28, 9, 0, 0, 0, time.Local),
+	}
+	next := time.Date(2020, time.July, 27, 8, 0, 0, 0, time.Local)
+	for i := range expected {
+		next = plan.NextExecution(next)
+		if !next.Equal(expected[i]) {
+			t.Errorf("expected %v at index %d but received %v", expected[i], i, next)
+		}
+	}
+
+	tests := []struct {
+		T        time.Time
+		Expected []int
+	}{
+		{time.Date(2020, time.July, 27, 9, 0, 0, 0, time.Local), []int{0, 1}},
+		{time.Date(2020, time.July, 27, 9, 0, 30, 0, time.Local), []int{0, 1}},
+		{time.Date(2020, time.July, 27, 9, 30, 0, 0, time.Local), []int{1}},
+		{time.Date(2020, time.July, 28, 9, 0, 0, 0, time.Local), []int{0}},
+		{time.Date(2020, time.July, 28, 9, 30, 0, 0, time.Local), []int{}},
+	}
+
+	for _, test := range tests {
+		matching := plan.Matching(test.T)
+		if len(matching) != len(test.Expected) {
+			t.Errorf("expected matching %v at %v but received %v", test.Expected, test.T, matching)
+			continue
+		}
+		for i := range test.Expected {
+			if matching[i] != test.Expected[i] {
+				t.Errorf("expected matching %v at %v but received %v", test.Expected, test.T, matching)
+				break
+			}
+		}
+	}
+}
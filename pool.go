@@ -0,0 +1,68 @@
+package cronschedule
+
+import "sync"
+
+// schedulePool holds the storage of released schedules so EmptySchedule, and thus Parse, may reuse it rather than
+// allocating new maps for every schedule.
+var schedulePool = sync.Pool{
+	New: func() interface{} {
+		return newEmptySchedule()
+	},
+}
+
+// Release resets the schedule and returns its storage to an internal pool to be reused by later calls to Parse. It is
+// intended for servers parsing large numbers of short lived schedules. The schedule, and any copies of it, must not be
+// used after it is released.
+func (s *Schedule) Release() {
+	released := *s
+	released.reset()
+	schedulePool.Put(&released)
+
+	// Clearing the released schedule so any further use does not reach the pooled storage.
+	*s = Schedule{}
+}
+
+// reset empties every field of the schedule while retaining the allocated storage.
+func (s *Schedule) reset() {
+	s.Minutes = clearMap(s.Minutes)
+	s.Hours = clearMap(s.Hours)
+	s.DaysOfMonth = clearMap(s.DaysOfMonth)
+	s.Months = clearMap(s.Months)
+	s.DaysOfTheWeek = clearMap(s.DaysOfTheWeek)
+
+	s.MinutesStr = s.MinutesStr[:0]
+	s.HoursStr = s.HoursStr[:0]
+	s.DaysOfMonthStr = s.DaysOfMonthStr[:0]
+	s.MonthsStr = s.MonthsStr[:0]
+	s.DaysOfTheWeekStr = s.DaysOfTheWeekStr[:0]
+
+	s.MinutesSlice = s.MinutesSlice[:0]
+	s.HoursSlice = s.HoursSlice[:0]
+	s.DaysOfMonthSlice = s.DaysOfMonthSlice[:0]
+	s.MonthsSlice = s.MonthsSlice[:0]
+	s.DaysOfWeekSlice = s.DaysOfWeekSlice[:0]
+
+	s.NthDaysOfTheWeek = s.NthDaysOfTheWeek[:0]
+
+	s.DaysOfMonthCleared = false
+	s.DaysOfTheWeekCleared = false
+
+	s.ScheduleStr = ""
+	s.Location = nil
+	s.clock = nil
+	s.offset = 0
+	s.canonical = ""
+	s.canonicalLocation = nil
+}
+
+// clearMap deletes every key of the map provided, allocating a new map if it is nil.
+func clearMap(m map[int]int) map[int]int {
+	if m == nil {
+		return make(map[int]int)
+	}
+
+	for k := range m {
+		delete(m, k)
+	}
+	return m
+}
@@ -0,0 +1,54 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+)
+
+func TestReleaseReuse(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		first, err := cronschedule.Parse("0,30 9-17 1,15 1-6 *")
+		if err != nil {
+			t.Fatalf("failed to build schedule: %s", err)
+		}
+		first.Release()
+
+		second, err := cronschedule.Parse("5 22 * 8 1-5")
+		if err != nil {
+			t.Fatalf("failed to build schedule: %s", err)
+		}
+
+		if str := second.String(); str != "5 22 * 8 1-5" {
+			t.Fatalf("expected released values not to carry over but received %s", str)
+		}
+
+		if len(second.MinutesStr) != 1 || len(second.DaysOfMonth) != 0 {
+			t.Fatalf("expected released field strings and values not to carry over but received %v and %v", second.MinutesStr, second.DaysOfMonth)
+		}
+		second.Release()
+	}
+
+	schedule, err := cronschedule.Parse("* * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	schedule.Release()
+	if schedule.Minutes != nil {
+		t.Errorf("expected released schedule to be cleared")
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = cronschedule.Parse("*/5 9-17 * * 1-5")
+	}
+}
+
+func BenchmarkParseRelease(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		schedule, _ := cronschedule.Parse("*/5 9-17 * * 1-5")
+		schedule.Release()
+	}
+}
@@ -0,0 +1,48 @@
+package cronschedule
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// ParseRandomized is the same as Parse but also supports a~b values, such as 0~30 in the minute field, which are
+// replaced by a random value from a through b inclusive chosen once when the schedule is parsed. The values are chosen
+// from a random source seeded with _seed_ in order from the first field to the last, so parsing the same schedule with
+// the same seed always chooses the same values. Seeding with a value unique to each host spreads the executions of a
+// job across hosts while keeping the executions of each host stable.
+func ParseRandomized(s string, seed int64) (Schedule, error) {
+	return ParseWithOptions(s, ParseOptions{Random: rand.New(rand.NewSource(seed))})
+}
+
+// randomValue chooses a value for the a~b value _value_ of a field allowing _min_ through _max_ using _random_. The
+// value chosen is provided as a string to be parsed the same as any single value.
+func randomValue(value string, min int, max int, random *rand.Rand) (string, error) {
+	if random == nil {
+		return "", fmt.Errorf("~ is only supported by ParseRandomized")
+	}
+
+	tokens := strings.Split(value, "~")
+	if len(tokens) != 2 {
+		return "", fmt.Errorf("expected a single ~ operator")
+	}
+
+	start, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse random range start %s: %s", tokens[0], err)
+	}
+	end, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse random range end %s: %s", tokens[1], err)
+	}
+
+	if start < min || end > max {
+		return "", fmt.Errorf("random range %d~%d is not between %d and %d", start, end, min, max)
+	}
+	if start > end {
+		return "", fmt.Errorf("random range start %d is after end %d", start, end)
+	}
+
+	return strconv.Itoa(start + random.Intn(end-start+1)), nil
+}
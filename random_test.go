@@ -0,0 +1,91 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+)
+
+func TestParseRandomizedDeterministic(t *testing.T) {
+	for _, expr := range []string{"0~30 * * * *", "0~59 0~23 * * MON~FRI", "CRON_TZ=UTC 15~45 2~4 1~28 * *"} {
+		for seed := int64(0); seed < 20; seed++ {
+			first, err := cronschedule.ParseRandomized(expr, seed)
+			if err != nil {
+				t.Fatalf("failed to build schedule for %s: %s", expr, err)
+			}
+
+			second, err := cronschedule.ParseRandomized(expr, seed)
+			if err != nil {
+				t.Fatalf("failed to build schedule for %s: %s", expr, err)
+			}
+
+			if !first.Equal(&second) {
+				t.Errorf("expected seed %d to choose the same values for %s but received %s and %s", seed, expr, first.String(), second.String())
+			}
+		}
+	}
+
+	// Different seeds spread the value chosen.
+	chosen := make(map[int]bool)
+	for seed := int64(0); seed < 50; seed++ {
+		schedule, err := cronschedule.ParseRandomized("0~59 * * * *", seed)
+		if err != nil {
+			t.Fatalf("failed to build schedule: %s", err)
+		}
+		chosen[schedule.MinutesSlice[0]] = true
+	}
+	if len(chosen) < 2 {
+		t.Errorf("expected different seeds to choose different minutes but received %v", chosen)
+	}
+}
+
+func TestParseRandomizedBounds(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		Index    int
+		Min      int
+		Max      int
+	}{
+		{"0~30 * * * *", 0, 0, 30},
+		{"0 9~17 * * *", 1, 9, 17},
+		{"0 0 10~12 * *", 2, 10, 12},
+		{"0 0 1 JUN~AUG *", 3, 6, 8},
+		{"0 0 * * 1~5", 4, 1, 5},
+		{"5~5 * * * *", 0, 5, 5},
+	}
+
+	for _, test := range tests {
+		for seed := int64(0); seed < 100; seed++ {
+			schedule, err := cronschedule.ParseRandomized(test.Schedule, seed)
+			if err != nil {
+				t.Fatalf("failed to build schedule for %s: %s", test.Schedule, err)
+			}
+
+			values := schedule.FieldValues(test.Index)
+			if len(values) != 1 || values[0] < test.Min || values[0] > test.Max {
+				t.Errorf("expected a single value between %d and %d for %s with seed %d but received %v", test.Min, test.Max, test.Schedule, seed, values)
+			}
+		}
+	}
+}
+
+func TestParseRandomizedErrors(t *testing.T) {
+	tests := []string{
+		"30~0 * * * *",
+		"0~60 * * * *",
+		"0 0~24 * * *",
+		"1~2~3 * * * *",
+		"~ * * * *",
+		"a~5 * * * *",
+	}
+
+	for _, test := range tests {
+		if _, err := cronschedule.ParseRandomized(test, 1); err == nil {
+			t.Errorf("expected an error for %s", test)
+		}
+	}
+
+	// Parse does not choose random values.
+	if _, err := cronschedule.Parse("0~30 * * * *"); err == nil {
+		t.Errorf("expected an error parsing ~ without ParseRandomized")
+	}
+}
@@ -0,0 +1,225 @@
+package cronschedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleDays contains the iCalendar day codes indexed by the POSIX day of week number.
+var rruleDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ToRRULE generates an iCalendar RRULE approximating the schedule, e.g. FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=22;
+// BYMINUTE=0 for 0 22 * * 1-5. The BYHOUR and BYMINUTE parts are always included so the rule does not depend on the
+// time of its DTSTART. The location of the schedule is not included as it belongs to the DTSTART of the event.
+//
+// A schedule restricted by both the day of month and the day of week cannot be represented as RRULE combines
+// BYMONTHDAY and BYDAY with AND while cron ORs them, so an error is provided. An error is also provided for a schedule
+// that can never execute.
+func (s *Schedule) ToRRULE() (string, error) {
+	if !s.IsSatisfiable() {
+		return "", fmt.Errorf("schedule never executes")
+	}
+
+	parts := make([]string, 0, 5)
+	everyDay := s.includesEveryDay()
+	switch {
+	case everyDay:
+		parts = append(parts, "FREQ=DAILY")
+	case len(s.DaysOfMonth) != 0 && s.hasDayOfWeek():
+		return "", fmt.Errorf("day of month and day of week cannot both be represented as RRULE requires both to match")
+	case len(s.DaysOfMonth) != 0:
+		parts = append(parts, "FREQ=MONTHLY")
+	case len(s.NthDaysOfTheWeek) != 0:
+		parts = append(parts, "FREQ=MONTHLY")
+	default:
+		parts = append(parts, "FREQ=WEEKLY")
+	}
+
+	if !s.IsWildcard(3) {
+		parts = append(parts, "BYMONTH="+joinInts(sortMapKeys(s.Months)))
+	}
+
+	if !everyDay {
+		if len(s.DaysOfMonth) != 0 {
+			parts = append(parts, "BYMONTHDAY="+joinInts(sortMapKeys(s.DaysOfMonth)))
+		} else {
+			days := make([]string, 0, len(s.DaysOfTheWeek)+len(s.NthDaysOfTheWeek))
+			for _, day := range sortMapKeys(s.DaysOfTheWeek) {
+				days = append(days, rruleDays[day])
+			}
+			for _, nth := range s.NthDaysOfTheWeek {
+				days = append(days, strconv.Itoa(nth.N)+rruleDays[nth.Weekday])
+			}
+			parts = append(parts, "BYDAY="+strings.Join(days, ","))
+		}
+	}
+
+	parts = append(parts, "BYHOUR="+joinInts(sortMapKeys(s.Hours)))
+	parts = append(parts, "BYMINUTE="+joinInts(sortMapKeys(s.Minutes)))
+	return strings.Join(parts, ";"), nil
+}
+
+// FromRRULE parses a subset of the iCalendar RRULE _rrule_ into a schedule. An optional RRULE: prefix is allowed.
+//
+// FREQ, INTERVAL, BYMONTH, BYMONTHDAY, BYDAY, BYHOUR, and BYMINUTE are supported along with WKST, which has no effect.
+// As there is no DTSTART to take the time of day from, BYMINUTE is required for every frequency other than MINUTELY
+// and BYHOUR is required for DAILY and longer frequencies. WEEKLY requires BYDAY and MONTHLY requires BYMONTHDAY or
+// BYDAY, where BYDAY may contain an occurrence from the start of the month such as 1FR. YEARLY requires BYMONTH along
+// with BYMONTHDAY or BYDAY without occurrences. An INTERVAL greater than 1 is only supported for MINUTELY and HOURLY
+// rules where it evenly divides the hour or day. COUNT, UNTIL, and every other part result in an error, as does
+// combining BYMONTHDAY with BYDAY as cron ORs the day fields while RRULE requires both to match.
+func FromRRULE(rrule string) (Schedule, error) {
+	rrule = strings.TrimPrefix(strings.TrimSpace(rrule), "RRULE:")
+
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rrule, ";") {
+		tokens := strings.SplitN(part, "=", 2)
+		if len(tokens) != 2 || tokens[1] == "" {
+			return EmptySchedule(), fmt.Errorf("invalid RRULE part [%s]", part)
+		}
+
+		name := strings.ToUpper(tokens[0])
+		if _, ok := parts[name]; ok {
+			return EmptySchedule(), fmt.Errorf("RRULE part %s specified more than once", name)
+		}
+		parts[name] = strings.ToUpper(tokens[1])
+	}
+
+	for name := range parts {
+		switch name {
+		case "FREQ", "INTERVAL", "BYMONTH", "BYMONTHDAY", "BYDAY", "BYHOUR", "BYMINUTE", "WKST":
+		default:
+			return EmptySchedule(), fmt.Errorf("RRULE part %s is not supported", name)
+		}
+	}
+
+	interval := 1
+	if value, ok := parts["INTERVAL"]; ok {
+		var err error
+		interval, err = strconv.Atoi(value)
+		if err != nil || interval < 1 {
+			return EmptySchedule(), fmt.Errorf("invalid RRULE INTERVAL [%s]", value)
+		}
+	}
+
+	fields := []string{parts["BYMINUTE"], parts["BYHOUR"], parts["BYMONTHDAY"], parts["BYMONTH"], "*"}
+	freq := parts["FREQ"]
+	switch freq {
+	case "MINUTELY":
+		if fields[0] == "" {
+			fields[0] = "*"
+		}
+		if fields[1] == "" {
+			fields[1] = "*"
+		}
+	case "HOURLY":
+		if fields[1] == "" {
+			fields[1] = "*"
+		}
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	case "":
+		return EmptySchedule(), fmt.Errorf("RRULE FREQ is required")
+	default:
+		return EmptySchedule(), fmt.Errorf("RRULE FREQ=%s is not supported", freq)
+	}
+
+	if interval > 1 {
+		switch {
+		case freq == "MINUTELY" && fields[0] == "*" && 60%interval == 0:
+			fields[0] = "*/" + strconv.Itoa(interval)
+		case freq == "HOURLY" && fields[1] == "*" && 24%interval == 0:
+			fields[1] = "*/" + strconv.Itoa(interval)
+		default:
+			return EmptySchedule(), fmt.Errorf("RRULE INTERVAL=%d is not supported for FREQ=%s", interval, freq)
+		}
+	}
+
+	if fields[0] == "" {
+		return EmptySchedule(), fmt.Errorf("RRULE BYMINUTE is required for FREQ=%s", freq)
+	}
+	if fields[1] == "" {
+		return EmptySchedule(), fmt.Errorf("RRULE BYHOUR is required for FREQ=%s", freq)
+	}
+
+	if byDay, ok := parts["BYDAY"]; ok {
+		days := make([]string, 0, 7)
+		for _, day := range strings.Split(byDay, ",") {
+			cronDay, err := rruleDay(day, freq == "MONTHLY")
+			if err != nil {
+				return EmptySchedule(), err
+			}
+			days = append(days, cronDay)
+		}
+		fields[4] = strings.Join(days, ",")
+	}
+
+	switch {
+	case fields[2] != "" && fields[4] != "*":
+		return EmptySchedule(), fmt.Errorf("RRULE BYMONTHDAY and BYDAY cannot be combined as cron executes when either matches")
+	case freq == "WEEKLY" && fields[4] == "*":
+		return EmptySchedule(), fmt.Errorf("RRULE BYDAY is required for FREQ=WEEKLY")
+	case freq == "MONTHLY" && fields[2] == "" && fields[4] == "*":
+		return EmptySchedule(), fmt.Errorf("RRULE BYMONTHDAY or BYDAY is required for FREQ=MONTHLY")
+	case freq == "YEARLY" && (fields[3] == "" || fields[2] == "" && fields[4] == "*"):
+		return EmptySchedule(), fmt.Errorf("RRULE BYMONTH along with BYMONTHDAY or BYDAY is required for FREQ=YEARLY")
+	}
+
+	if fields[2] == "" {
+		fields[2] = "*"
+	}
+	if fields[3] == "" {
+		fields[3] = "*"
+	}
+
+	schedule, err := Parse(strings.Join(fields, " "))
+	if err != nil {
+		return schedule, fmt.Errorf("failed to convert RRULE: %s", err)
+	}
+	return schedule, nil
+}
+
+// rruleDay converts the RRULE BYDAY value _day_ to a cron day of week value. Occurrences such as 1FR are converted to
+// the # operator when _allowOccurrence_ is true.
+func rruleDay(day string, allowOccurrence bool) (string, error) {
+	if len(day) < 2 {
+		return "", fmt.Errorf("invalid RRULE BYDAY value [%s]", day)
+	}
+
+	code := day[len(day)-2:]
+	weekday := -1
+	for i, rruleDay := range rruleDays {
+		if rruleDay == code {
+			weekday = i
+			break
+		}
+	}
+	if weekday < 0 {
+		return "", fmt.Errorf("invalid RRULE BYDAY value [%s]", day)
+	}
+
+	occurrence := day[:len(day)-2]
+	if occurrence == "" {
+		return strconv.Itoa(weekday), nil
+	}
+
+	if !allowOccurrence {
+		return "", fmt.Errorf("RRULE BYDAY occurrence [%s] is only supported for FREQ=MONTHLY", day)
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(occurrence, "+"))
+	if err != nil || n < FieldNthWeekdayMin || n > FieldNthWeekdayMax {
+		return "", fmt.Errorf("RRULE BYDAY occurrence [%s] must be between %d and %d", day, FieldNthWeekdayMin, FieldNthWeekdayMax)
+	}
+	return NthWeekday{Weekday: time.Weekday(weekday), N: n}.String(), nil
+}
+
+// joinInts joins the values as a comma separated list.
+func joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = strconv.Itoa(value)
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,129 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+)
+
+func TestToRRULE(t *testing.T) {
+	tests := []struct {
+		Schedule string
+		RRULE    string
+		Error    bool
+	}{
+		{"0 22 * * 1-5", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=22;BYMINUTE=0", false},
+		{"30 6 * * *", "FREQ=DAILY;BYHOUR=6;BYMINUTE=30", false},
+		{"*/15 9-11 * * *", "FREQ=DAILY;BYHOUR=9,10,11;BYMINUTE=0,15,30,45", false},
+		{"0 0 1,15 * *", "FREQ=MONTHLY;BYMONTHDAY=1,15;BYHOUR=0;BYMINUTE=0", false},
+		{"0 12 25 12 *", "FREQ=MONTHLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=12;BYMINUTE=0", false},
+		{"0 9 * * 5#1", "FREQ=MONTHLY;BYDAY=1FR;BYHOUR=9;BYMINUTE=0", false},
+		{"0 9 * * 1,5#1", "FREQ=MONTHLY;BYDAY=MO,1FR;BYHOUR=9;BYMINUTE=0", false},
+		{"0 9 * 1-3 0", "FREQ=WEEKLY;BYMONTH=1,2,3;BYDAY=SU;BYHOUR=9;BYMINUTE=0", false},
+		{"0 9 1 * 1", "", true},
+		{"0 0 31 2 *", "", true},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		rrule, err := schedule.ToRRULE()
+		switch {
+		case test.Error && err == nil:
+			t.Errorf("expected an error for %s but received %s", test.Schedule, rrule)
+		case !test.Error && err != nil:
+			t.Errorf("expected %s to convert but received %s", test.Schedule, err)
+		case rrule != test.RRULE:
+			t.Errorf("expected RRULE [%s] for %s but received [%s]", test.RRULE, test.Schedule, rrule)
+		}
+	}
+}
+
+func TestFromRRULE(t *testing.T) {
+	tests := []struct {
+		RRULE    string
+		Schedule string
+		Error    bool
+	}{
+		{"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=22;BYMINUTE=0", "0 22 * * 1-5", false},
+		{"RRULE:FREQ=DAILY;BYHOUR=6;BYMINUTE=30", "30 6 * * *", false},
+		{"freq=monthly;byday=1fr;byhour=9;byminute=0", "0 9 * * 5#1", false},
+		{"FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=12;BYMINUTE=0", "0 12 25 12 *", false},
+		{"FREQ=MINUTELY;INTERVAL=15", "*/15 * * * *", false},
+		{"FREQ=HOURLY;INTERVAL=6;BYMINUTE=5", "5 */6 * * *", false},
+		{"FREQ=DAILY;BYHOUR=8;BYMINUTE=0;WKST=MO", "0 8 * * *", false},
+		{"FREQ=DAILY;BYHOUR=8;BYMINUTE=0;COUNT=10", "", true},
+		{"FREQ=DAILY;BYHOUR=8;BYMINUTE=0;UNTIL=20300101T000000Z", "", true},
+		{"FREQ=MINUTELY;INTERVAL=7", "", true},
+		{"FREQ=DAILY;INTERVAL=2;BYHOUR=8;BYMINUTE=0", "", true},
+		{"FREQ=DAILY;BYMINUTE=0", "", true},
+		{"FREQ=WEEKLY;BYHOUR=8;BYMINUTE=0", "", true},
+		{"FREQ=WEEKLY;BYDAY=1MO;BYHOUR=8;BYMINUTE=0", "", true},
+		{"FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=8;BYMINUTE=0", "", true},
+		{"FREQ=MONTHLY;BYMONTHDAY=1;BYDAY=MO;BYHOUR=8;BYMINUTE=0", "", true},
+		{"FREQ=SECONDLY", "", true},
+		{"BYHOUR=8;BYMINUTE=0", "", true},
+		{"FREQ=DAILY;BYHOUR=24;BYMINUTE=0", "", true},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.FromRRULE(test.RRULE)
+		switch {
+		case test.Error && err == nil:
+			t.Errorf("expected an error for %s but received %s", test.RRULE, schedule.String())
+			continue
+		case !test.Error && err != nil:
+			t.Errorf("expected %s to convert but received %s", test.RRULE, err)
+			continue
+		case test.Error:
+			continue
+		}
+
+		expected, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+		if !schedule.Equal(&expected) {
+			t.Errorf("expected %s for %s but received %s", test.Schedule, test.RRULE, schedule.String())
+		}
+	}
+}
+
+func TestRRULERoundTrip(t *testing.T) {
+	tests := []string{
+		"0 22 * * 1-5",
+		"*/15 9-11 * * *",
+		"0 0 1,15 * *",
+		"0 12 25 12 *",
+		"0 9 * * 1,5#1",
+		"0 9 * 1-3 0",
+		"* * * * *",
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test, err)
+			continue
+		}
+
+		rrule, err := schedule.ToRRULE()
+		if err != nil {
+			t.Errorf("failed to convert %s to RRULE: %s", test, err)
+			continue
+		}
+
+		roundTrip, err := cronschedule.FromRRULE(rrule)
+		if err != nil {
+			t.Errorf("failed to convert %s from RRULE: %s", rrule, err)
+			continue
+		}
+		if !roundTrip.Equal(&schedule) {
+			t.Errorf("expected %s to round trip through %s but received %s", test, rrule, roundTrip.String())
+		}
+	}
+}
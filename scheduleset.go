@@ -0,0 +1,82 @@
+package cronschedule
+
+import "time"
+
+// ScheduleSet holds many schedules and determines which of them are due at a time. Schedules are bucketed by the
+// minutes they execute on so schedules that cannot execute at a time are skipped without being evaluated.
+// NewScheduleSet should be utilized to generate ScheduleSets.
+type ScheduleSet struct {
+	schedules []Schedule
+
+	// byMinute contains the indexes of the schedules executing on each minute.
+	byMinute [FieldMinuteMax + 1][]int
+
+	// located contains the indexes of schedules with a Location. The minute of a time can differ between locations
+	// so these schedules are always evaluated.
+	located []int
+}
+
+// NewScheduleSet generates a ScheduleSet containing _schedules_. The index of each schedule in the set matches its
+// index in _schedules_.
+func NewScheduleSet(schedules []Schedule) *ScheduleSet {
+	set := &ScheduleSet{
+		schedules: make([]Schedule, 0, len(schedules)),
+	}
+	for _, s := range schedules {
+		set.Add(s)
+	}
+	return set
+}
+
+// Add adds the schedule _s_ to the set and returns its index.
+func (ss *ScheduleSet) Add(s Schedule) int {
+	index := len(ss.schedules)
+	ss.schedules = append(ss.schedules, s)
+
+	if s.Location != nil {
+		ss.located = append(ss.located, index)
+		return index
+	}
+
+	for minute := range s.Minutes {
+		if minute < FieldMinuteMin || minute > FieldMinuteMax {
+			continue
+		}
+		ss.byMinute[minute] = append(ss.byMinute[minute], index)
+	}
+	return index
+}
+
+// Len returns the number of schedules in the set.
+func (ss *ScheduleSet) Len() int {
+	return len(ss.schedules)
+}
+
+// Schedule returns the schedule at _index_.
+func (ss *ScheduleSet) Schedule(index int) *Schedule {
+	return &ss.schedules[index]
+}
+
+// DueAt returns the indexes, in ascending order, of the schedules that should be executed at time _t_.
+func (ss *ScheduleSet) DueAt(t time.Time) []int {
+	candidates := ss.byMinute[t.Minute()]
+	due := make([]int, 0)
+
+	// Merging the located schedules with the minute bucket so the indexes remain in ascending order.
+	i, j := 0, 0
+	for i < len(candidates) || j < len(ss.located) {
+		var index int
+		if j >= len(ss.located) || (i < len(candidates) && candidates[i] < ss.located[j]) {
+			index = candidates[i]
+			i++
+		} else {
+			index = ss.located[j]
+			j++
+		}
+
+		if ss.schedules[index].ShouldExecute(t) {
+			due = append(due, index)
+		}
+	}
+	return due
+}
@@ -0,0 +1,91 @@
+package cronschedule_test
+
+import (
+	"fmt"
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+	"time"
+)
+
+// syntheticSchedules generates count schedules spread across minutes, hours, and days of the week.
+func syntheticSchedules(count int) []cronschedule.Schedule {
+	schedules := make([]cronschedule.Schedule, 0, count)
+	for i := 0; i < count; i++ {
+		expr := fmt.Sprintf("%d %d * * %d", i%60, (i/60)%24, i%7)
+		if i%10 == 0 {
+			expr = fmt.Sprintf("%d,%d * * * *", i%60, (i+30)%60)
+		}
+
+		schedule, err := cronschedule.Parse(expr)
+		if err != nil {
+			panic(err)
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
+
+func TestScheduleSetDueAt(t *testing.T) {
+	schedules := syntheticSchedules(2000)
+
+	located, err := cronschedule.Parse("CRON_TZ=UTC 0 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+	schedules = append(schedules, located)
+
+	set := cronschedule.NewScheduleSet(schedules)
+	if set.Len() != len(schedules) {
+		t.Fatalf("expected %d schedules but received %d", len(schedules), set.Len())
+	}
+
+	start := time.Date(2020, time.July, 23, 0, 0, 0, 0, time.UTC)
+	for minute := 0; minute < 24*60; minute += 7 {
+		at := start.Add(time.Duration(minute) * time.Minute)
+
+		expected := make([]int, 0)
+		for i := range schedules {
+			if schedules[i].ShouldExecute(at) {
+				expected = append(expected, i)
+			}
+		}
+
+		due := set.DueAt(at)
+		if len(due) != len(expected) {
+			t.Errorf("expected %d schedules due at %v but received %d", len(expected), at, len(due))
+			continue
+		}
+
+		for i := range expected {
+			if due[i] != expected[i] {
+				t.Errorf("expected index %d due at %v but received %d", expected[i], at, due[i])
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkScheduleSetDueAt(b *testing.B) {
+	set := cronschedule.NewScheduleSet(syntheticSchedules(10000))
+	at := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = set.DueAt(at)
+	}
+}
+
+func BenchmarkShouldExecuteLoop(b *testing.B) {
+	schedules := syntheticSchedules(10000)
+	at := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		due := make([]int, 0)
+		for j := range schedules {
+			if schedules[j].ShouldExecute(at) {
+				due = append(due, j)
+			}
+		}
+	}
+}
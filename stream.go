@@ -0,0 +1,49 @@
+package cronschedule
+
+import (
+	"context"
+	"time"
+)
+
+// streamBatchSize is the number of execution times generated at once by Stream.
+const streamBatchSize = 32
+
+// Stream returns a channel emitting successive execution times after _from_. Times are computed immediately rather
+// than when they occur, allowing schedules to be pre-generated by consuming from the channel. The channel is closed
+// once _ctx_ is cancelled, even while a batch of times is being searched for, and is closed immediately if the schedule
+// can never execute.
+func (s *Schedule) Stream(ctx context.Context, from time.Time) <-chan time.Time {
+	ch := make(chan time.Time)
+
+	go func() {
+		defer close(ch)
+
+		if !s.IsSatisfiable() {
+			return
+		}
+
+		next := from
+		for {
+			execTimes, err := s.nextExecutions(ctx, next, streamBatchSize)
+			if err != nil {
+				return
+			}
+
+			for _, execT := range execTimes {
+				// Checking for cancellation first as select chooses randomly when a receiver is also ready.
+				if ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case ch <- execT:
+					next = execT
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch
+}
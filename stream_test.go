@@ -0,0 +1,70 @@
+package cronschedule_test
+
+import (
+	"context"
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	schedule, err := cronschedule.Parse("*/10 * * * *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	from := time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local)
+	expected := schedule.NextExecutions(from, 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := schedule.Stream(ctx, from)
+
+	for i := range expected {
+		execT := <-stream
+		if !execT.Equal(expected[i]) {
+			t.Fatalf("expected %v at index %d but received %v", expected[i], i, execT)
+		}
+	}
+
+	cancel()
+
+	// At most one value may already be pending before the channel is closed.
+	timeout := time.After(time.Second)
+	pending := 0
+	for {
+		select {
+		case _, ok := <-stream:
+			if !ok {
+				return
+			}
+
+			pending++
+			if pending > 1 {
+				t.Fatalf("expected channel to close after cancellation")
+			}
+		case <-timeout:
+			t.Fatalf("expected channel to close after cancellation")
+		}
+	}
+}
+
+func TestStreamUnsatisfiable(t *testing.T) {
+	// February 30 never occurs so nothing is ever sent.
+	schedule, err := cronschedule.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("failed to build schedule: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := schedule.Stream(ctx, time.Date(2020, time.July, 23, 15, 28, 0, 0, time.Local))
+	cancel()
+
+	select {
+	case _, ok := <-stream:
+		if ok {
+			t.Fatalf("expected no times from a schedule that never executes")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected channel to close after cancellation")
+	}
+}
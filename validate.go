@@ -0,0 +1,87 @@
+package cronschedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Validate returns an error describing the first conflict found that prevents part of the schedule from ever
+// executing. A field without any values, other than a day field cleared by Parse, is a conflict. An allowed month that
+// is too short for every value of the day of month field is also a conflict, such as February for 30-31, even when
+// other months or the day of week keep the schedule executing, as the day of month was specified but can never match
+// in that month. The error names each such month. Nil is returned when no conflict is found.
+func (s *Schedule) Validate() error {
+	for _, i := range []int{0, 1, 3} {
+		if len(s.fieldMapByIndex(i)) == 0 {
+			return fmt.Errorf("%s field has no values", fieldNameByIndex(i))
+		}
+	}
+
+	if len(s.DaysOfMonth) == 0 && !s.hasDayOfWeek() {
+		return fmt.Errorf("day of month and day of week fields have no values")
+	}
+
+	if months := s.monthsMissingDayOfMonth(); len(s.DaysOfMonth) != 0 && len(months) != 0 {
+		return fmt.Errorf("day of month %s never occurs in %s", formatValueList(sortMapKeys(s.DaysOfMonth)), strings.Join(months, ","))
+	}
+
+	return nil
+}
+
+// IsSatisfiable returns true if the schedule executes at least once. Unlike Validate a day of month that never occurs
+// is allowed as long as the day of week keeps the schedule executing.
+func (s *Schedule) IsSatisfiable() bool {
+	if len(s.Minutes) == 0 || len(s.Hours) == 0 || len(s.Months) == 0 {
+		return false
+	}
+
+	// Every day of the week occurs in every month. Every occurrence of a day of the week, including the 5th, also
+	// occurs in every month of some year as February has 29 days in leap years.
+	if s.hasDayOfWeek() {
+		return true
+	}
+
+	return len(s.DaysOfMonth) != 0 && s.dayOfMonthReachable()
+}
+
+// monthsMissingDayOfMonth returns the names of the allowed months in ascending order in which none of the day of month
+// values occur. February is considered to have 29 days as it does in leap years.
+func (s *Schedule) monthsMissingDayOfMonth() []string {
+	months := make([]string, 0)
+	for _, month := range s.MonthsTyped() {
+		// Using a leap year so February 29 is considered reachable.
+		days := daysPerMonth(month, 2000)
+		reachable := false
+		for day := range s.DaysOfMonth {
+			if day <= days {
+				reachable = true
+				break
+			}
+		}
+
+		if !reachable {
+			months = append(months, month.String())
+		}
+	}
+	return months
+}
+
+// dayOfMonthReachable returns true if any day of month value occurs in any allowed month. February is considered to
+// have 29 days as it does in leap years.
+func (s *Schedule) dayOfMonthReachable() bool {
+	for month := range s.Months {
+		if month < FieldMonthMin || month > FieldMonthMax {
+			continue
+		}
+
+		// Using a leap year so February 29 is considered reachable.
+		days := daysPerMonth(time.Month(month), 2000)
+		for day := range s.DaysOfMonth {
+			if day <= days {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package cronschedule_test
+
+import (
+	"github.com/jrmycanady/cronschedule"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		Schedule    string
+		Error       string
+		Satisfiable bool
+	}{
+		{"0 0 31 4 1", "day of month 31 never occurs in April", true},
+		{"0 0 31 4,6 *", "day of month 31 never occurs in April,June", false},
+		{"0 0 31 4,5 *", "day of month 31 never occurs in April", true},
+		{"0 0 29 2 *", "", true},
+		{"0 22 * * 1-5", "", true},
+		{"0 0 30-31 2 *", "day of month 30,31 never occurs in February", false},
+		{"0 0 30-31 2 1", "day of month 30,31 never occurs in February", true},
+		{"0 0 29-31 2 *", "", true},
+		{"0 0 1,31 2,4 *", "", true},
+		{"0 0 31 1-12 *", "day of month 31 never occurs in February,April,June,September,November", true},
+		{"0 0 30-31 2,4 *", "day of month 30,31 never occurs in February", true},
+	}
+
+	for _, test := range tests {
+		schedule, err := cronschedule.Parse(test.Schedule)
+		if err != nil {
+			t.Errorf("failed to build schedule for %s: %s", test.Schedule, err)
+			continue
+		}
+
+		err = schedule.Validate()
+		switch {
+		case test.Error == "" && err != nil:
+			t.Errorf("expected %s to be valid but received %s", test.Schedule, err)
+		case test.Error != "" && (err == nil || err.Error() != test.Error):
+			t.Errorf("expected error [%s] for %s but received [%v]", test.Error, test.Schedule, err)
+		}
+
+		if satisfiable := schedule.IsSatisfiable(); satisfiable != test.Satisfiable {
+			t.Errorf("expected %s satisfiable to be %t but received %t", test.Schedule, test.Satisfiable, satisfiable)
+		}
+	}
+}